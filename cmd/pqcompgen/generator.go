@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	tagName         = "pqcomp"
+	defaultOperator = "="
+)
+
+var basicTypes = map[string]bool{
+	"string": true, "bool": true, "byte": true, "rune": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true, "complex64": true, "complex128": true,
+}
+
+// generateDir parses non-test go files from given directory and generates code for given struct types.
+func generateDir(dir string, types []string) ([]byte, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") || strings.HasSuffix(name, "_pqcomp.go") {
+			continue
+		}
+		src, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(fset, name, src, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no go files found in %s", dir)
+	}
+
+	return generate(files, types)
+}
+
+// generate produces formatted source code with AddTo method for each of given struct types.
+func generate(files []*ast.File, types []string) ([]byte, error) {
+	structs := make(map[string]*ast.StructType)
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			if ts, ok := n.(*ast.TypeSpec); ok {
+				if st, ok := ts.Type.(*ast.StructType); ok {
+					structs[ts.Name.Name] = st
+				}
+			}
+			return true
+		})
+	}
+
+	g := &generator{structs: structs}
+	fmt.Fprintf(&g.buf, "// Code generated by pqcompgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&g.buf, "package %s\n\n", files[0].Name.Name)
+	fmt.Fprintf(&g.buf, "import \"github.com/piotrkowalczuk/pqcomp\"\n")
+
+	for _, name := range types {
+		st, ok := structs[name]
+		if !ok {
+			return nil, fmt.Errorf("struct %s not found", name)
+		}
+
+		fmt.Fprintf(&g.buf, "\n// AddTo adds expression for each tagged field of %s to the composer.\n", name)
+		fmt.Fprintf(&g.buf, "func (f *%s) AddTo(c *pqcomp.Composer) {\n", name)
+		fmt.Fprintf(&g.buf, "if f == nil {\nreturn\n}\n")
+		g.walking = map[string]bool{name: true}
+		if err := g.fields(st, "f"); err != nil {
+			return nil, fmt.Errorf("struct %s: %s", name, err.Error())
+		}
+		fmt.Fprintf(&g.buf, "}\n")
+	}
+
+	return format.Source(g.buf.Bytes())
+}
+
+type generator struct {
+	buf     bytes.Buffer
+	structs map[string]*ast.StructType
+	// walking holds names of structs that are being walked, so struct that embeds itself is walked only once.
+	walking map[string]bool
+}
+
+func (g *generator) fields(st *ast.StructType, path string) error {
+	for _, field := range st.Fields.List {
+		var (
+			tag string
+			ok  bool
+		)
+		if field.Tag != nil {
+			lit, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return err
+			}
+			tag, ok = reflect.StructTag(lit).Lookup(tagName)
+		}
+
+		names := field.Names
+		if len(names) == 0 {
+			if !ok {
+				if err := g.embedded(field.Type, path); err != nil {
+					return err
+				}
+				continue
+			}
+			// tagged embedded field is treated as any other field
+			if name := embeddedName(field.Type); name != nil {
+				names = []*ast.Ident{name}
+			}
+		}
+		if !ok || tag == "-" {
+			continue
+		}
+
+		key, operator := tag, defaultOperator
+		if i := strings.Index(tag, ","); i != -1 {
+			key, operator = strings.TrimSpace(tag[:i]), strings.TrimSpace(tag[i+1:])
+		}
+		if key == "" {
+			continue
+		}
+
+		for _, name := range names {
+			if !name.IsExported() {
+				continue
+			}
+			g.field(field.Type, path+"."+name.Name, strconv.Quote(key), strconv.Quote(operator))
+		}
+	}
+
+	return nil
+}
+
+// embedded walks fields of embedded struct declared in the same package.
+// Embedded types from other packages cannot be inspected without type checking, so they are reported as an error.
+func (g *generator) embedded(expr ast.Expr, path string) error {
+	star, isPtr := expr.(*ast.StarExpr)
+	if isPtr {
+		expr = star.X
+	}
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		return fmt.Errorf("embedded type %s.%s is declared in other package, tag it or declare its fields explicitly", e.X, e.Sel.Name)
+	case *ast.Ident:
+		st, ok := g.structs[e.Name]
+		if !ok || g.walking[e.Name] {
+			return nil
+		}
+		g.walking[e.Name] = true
+		defer delete(g.walking, e.Name)
+
+		path = path + "." + e.Name
+		if isPtr {
+			fmt.Fprintf(&g.buf, "if %s != nil {\n", path)
+			defer fmt.Fprintf(&g.buf, "}\n")
+		}
+		return g.fields(st, path)
+	default:
+		return nil
+	}
+}
+
+// embeddedName returns name of embedded field, nil if it cannot be determined.
+func embeddedName(expr ast.Expr) *ast.Ident {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e
+	case *ast.SelectorExpr:
+		return e.Sel
+	default:
+		return nil
+	}
+}
+
+func (g *generator) field(expr ast.Expr, value, key, operator string) {
+	switch {
+	case isPtr(expr, isBasic), isPtr(expr, isTime), isPtr(expr, isNull):
+		fmt.Fprintf(&g.buf, "if %s != nil {\nc.AddExpr(%s, %s, *%s)\n}\n", value, key, operator, value)
+	default:
+		fmt.Fprintf(&g.buf, "c.AddExpr(%s, %s, %s)\n", key, operator, value)
+	}
+}
+
+func isBasic(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && basicTypes[ident.Name]
+}
+
+func isTime(expr ast.Expr) bool {
+	return isSelector(expr, "time", "Time")
+}
+
+// isNull returns true for sql Null types, including generic sql.Null.
+func isNull(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.IndexExpr:
+		return isSelector(e.X, "sql", "Null")
+	case *ast.SelectorExpr:
+		pkg, ok := e.X.(*ast.Ident)
+		return ok && pkg.Name == "sql" && strings.HasPrefix(e.Sel.Name, "Null")
+	default:
+		return false
+	}
+}
+
+func isSelector(expr ast.Expr, pkg, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == pkg && sel.Sel.Name == name
+}
+
+func isPtr(expr ast.Expr, elem func(ast.Expr) bool) bool {
+	star, ok := expr.(*ast.StarExpr)
+	return ok && elem(star.X)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGenerateDir(t *testing.T) {
+	cases := map[string][]string{
+		"filter": {"UserFilter", "Pagination", "Node"},
+	}
+
+	for name, types := range cases {
+		dir := t.TempDir()
+		src, err := os.ReadFile(filepath.Join("testdata", name+".go"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		if err := os.WriteFile(filepath.Join(dir, name+".go"), src, 0644); err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		got, err := generateDir(dir, types)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err.Error())
+			continue
+		}
+
+		golden := filepath.Join("testdata", name+".golden")
+		if *update {
+			if err := os.WriteFile(golden, got, 0644); err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+		}
+		expected, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		if !bytes.Equal(expected, got) {
+			t.Errorf("%s: generated code does not match golden file, got:\n%s", name, got)
+		}
+		if err := typeCheck(src, got); err != nil {
+			t.Errorf("%s: generated code does not type check: %s", name, err.Error())
+		}
+	}
+}
+
+// typeCheck type checks generated code together with the source it was generated from.
+func typeCheck(src, generated []byte) error {
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for name, b := range map[string][]byte{"src.go": src, "src_pqcomp.go": generated} {
+		f, err := parser.ParseFile(fset, name, b, 0)
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err := conf.Check(files[0].Name.Name, fset, files, nil)
+	return err
+}
+
+func TestGenerateDir_foreign(t *testing.T) {
+	dir := t.TempDir()
+	src, err := os.ReadFile(filepath.Join("testdata", "foreign.go"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if err := os.WriteFile(filepath.Join(dir, "foreign.go"), src, 0644); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if _, err := generateDir(dir, []string{"Filter"}); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestGenerateDir_missing(t *testing.T) {
+	if _, err := generateDir("testdata", []string{"Missing"}); err == nil {
+		t.Errorf("expected error")
+	}
+}
@@ -0,0 +1,66 @@
+// Command pqcompgen generates AddTo method for structs with pqcomp tags.
+// Generated method adds expression for each tagged field to pqcomp.Composer without the use of reflection.
+//
+// Usage:
+//
+//	//go:generate pqcompgen -type=UserFilter
+//
+// Tags are resolved at generation time and each field is passed to Composer.AddExpr,
+// so the policy of the composer decides if it is used. Pointers to basic types, time.Time and sql Null types
+// are checked for nil and dereferenced upfront.
+// Tagged embedded fields are treated as any other field, the same way as by Composer.AddStruct.
+// Untagged embedded structs declared in the same package are walked as if their fields were declared in the outer struct.
+// Untagged embedded types from other packages cannot be inspected without type checking, so generation fails.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	typeNames = flag.String("type", "", "comma-separated list of struct names; must be set")
+	output    = flag.String("output", "", "output file name; default <directory>/<type>_pqcomp.go")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of pqcompgen:\n")
+	fmt.Fprintf(os.Stderr, "\tpqcompgen [flags] -type T [directory]\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("pqcompgen: ")
+	flag.Usage = usage
+	flag.Parse()
+
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	types := strings.Split(*typeNames, ",")
+
+	src, err := generateDir(dir, types)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out := *output
+	if out == "" {
+		out = filepath.Join(dir, strings.ToLower(types[0])+"_pqcomp.go")
+	}
+	if err := os.WriteFile(out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
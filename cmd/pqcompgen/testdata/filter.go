@@ -0,0 +1,40 @@
+package filter
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/piotrkowalczuk/pqcomp/cmd/pqcompgen/testdata/paging"
+)
+
+type Pagination struct {
+	CreatedAfter *time.Time `pqcomp:"u.created_at,>"`
+}
+
+type Status string
+
+// Appear implements pqcomp.Appearer interface.
+func (s Status) Appear() bool {
+	return s != ""
+}
+
+type UserFilter struct {
+	*Pagination
+	paging.Cursor `pqcomp:"u.id,>"`
+	Name          string            `pqcomp:"u.name"`
+	Age           *int64            `pqcomp:"u.age,>="`
+	IDs           []int64           `pqcomp:"u.id,IN"`
+	Email         sql.NullString    `pqcomp:"u.email,LIKE"`
+	Phone         *sql.NullString   `pqcomp:"u.phone"`
+	Score         sql.Null[float64] `pqcomp:"u.score,<"`
+	UpdatedAt     time.Time         `pqcomp:"u.updated_at,<="`
+	Status        Status            `pqcomp:"u.status"`
+	Ignored       string            `pqcomp:"-"`
+	Untagged      string
+	private       string `pqcomp:"u.private"`
+}
+
+type Node struct {
+	*Node
+	Name string `pqcomp:"n.name"`
+}
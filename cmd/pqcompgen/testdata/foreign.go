@@ -0,0 +1,7 @@
+package foreign
+
+import "github.com/piotrkowalczuk/pqcomp/cmd/pqcompgen/testdata/paging"
+
+type Filter struct {
+	paging.Window
+}
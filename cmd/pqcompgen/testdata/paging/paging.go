@@ -0,0 +1,7 @@
+package paging
+
+type Window struct {
+	AfterID *int64 `pqcomp:"u.id,>"`
+}
+
+type Cursor int64
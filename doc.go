@@ -1,15 +1,21 @@
 /*
 Package pqcomp provides dead simple query builder that support null types from sql package, but also provide interface Appearer.
 
+If type of the value is known upfront, generic Add function can be used instead of Composer.AddExpr
+to skip type switch and reflection. It does not avoid boxing, arguments are returned by Args as []interface{}.
+
 
 Benchmarks
 
-To be comprehensive solution, query builder needs to be optimized. Some of the benchmark results:
+To be comprehensive solution, query builder needs to be optimized. Some of the benchmark results (median of five runs):
+
+	BenchmarkComposer_AddExpr-4       	 5167839	       222.7 ns/op	      81 B/op	       0 allocs/op
+	BenchmarkAdd-4                    	 7628343	       193.7 ns/op	     102 B/op	       1 allocs/op
+	BenchmarkComposer_Placeholder-4   	11738634	        87.64 ns/op	       2 B/op	       1 allocs/op
+	BenchmarkComposer_Args-4          	296747553	         4.017 ns/op	       0 B/op	       0 allocs/op
+	BenchmarkComposer_New-4           	  125264	      8523 ns/op	    7488 B/op	       6 allocs/op
 
-	BenchmarkComposer_AddExpr-4      	 5000000	       470 ns/op	     100 B/op	       1 allocs/op
-	BenchmarkComposer_Placeholder-4  	10000000	       130 ns/op	       4 B/op	       2 allocs/op
-	BenchmarkComposer_Args-4         	300000000	         4.89 ns/op	       0 B/op	       0 allocs/op
-	BenchmarkComposer_New-4          	  200000	     15311 ns/op	    5120 B/op	       4 allocs/op
+BenchmarkComposer_AddExpr passes constant string, that compiler boxes without allocation, BenchmarkAdd boxes it at runtime.
 
 */
 package pqcomp
@@ -0,0 +1,140 @@
+package pqcomp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidIdent is returned if identifier does not match safe grammar.
+var ErrInvalidIdent = errors.New("pqcomp: invalid identifier")
+
+// ValidIdent returns true if key is a safe identifier that consist of up to three parts separated by dots,
+// like schema.table.column. Each part has to be either a plain identifier that starts with a letter or underscore
+// followed by letters, digits, underscores or dollar signs, or a double-quoted identifier with quotes escaped by doubling.
+func ValidIdent(key string) bool {
+	_, err := splitIdent(key)
+	return err == nil
+}
+
+// QuoteIdent validates key using the same rules as ValidIdent and quotes each of its parts:
+// u.first_name becomes "u"."first_name". Already quoted parts are left untouched.
+// Plain parts are folded to lower case, as PostgreSQL does with unquoted identifiers,
+// so u.firstName becomes "u"."firstname" and refers to the same column quoted or not.
+func QuoteIdent(key string) (string, error) {
+	parts, err := splitIdent(key)
+	if err != nil {
+		return "", err
+	}
+
+	var buf strings.Builder
+	for i, part := range parts {
+		if i > 0 {
+			buf.WriteString(".")
+		}
+		if part[0] == '"' {
+			buf.WriteString(part)
+			continue
+		}
+		buf.WriteString(`"`)
+		buf.WriteString(strings.ToLower(part))
+		buf.WriteString(`"`)
+	}
+
+	return buf.String(), nil
+}
+
+// SetStrict enables or disables strict mode. In strict mode each key passed to AddExpr and similar methods
+// is validated and quoted using QuoteIdent. Expressions with invalid key are not added,
+// instead an error is recorded and can be retrieved using Err.
+// Child composers inherit strict mode from the parent.
+func (c *Composer) SetStrict(strict bool) *Composer {
+	c.strict = strict
+	return c
+}
+
+func (c *Composer) isStrict() bool {
+	for cc := c; cc != nil; cc = cc.parent {
+		if cc.strict {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns first error recorded by the composer or any of its descendants.
+func (c *Composer) Err() error {
+	if len(c.errs) > 0 {
+		return c.errs[0]
+	}
+	for _, ch := range c.childs {
+		if err := ch.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func splitIdent(key string) ([]string, error) {
+	var parts []string
+
+	for rest := key; ; {
+		n, err := identPart(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidIdent, key)
+		}
+		parts = append(parts, rest[:n])
+		rest = rest[n:]
+
+		if rest == "" {
+			break
+		}
+		if rest[0] != '.' || len(parts) == 3 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidIdent, key)
+		}
+		rest = rest[1:]
+	}
+
+	return parts, nil
+}
+
+// identPart returns length of identifier part at the beginning of s.
+func identPart(s string) (int, error) {
+	if s == "" {
+		return 0, ErrInvalidIdent
+	}
+
+	if s[0] == '"' {
+		for i := 1; i < len(s); i++ {
+			if s[i] != '"' {
+				continue
+			}
+			if i+1 < len(s) && s[i+1] == '"' {
+				i++
+				continue
+			}
+			if i == 1 {
+				return 0, ErrInvalidIdent
+			}
+			return i + 1, nil
+		}
+		return 0, ErrInvalidIdent
+	}
+
+	if !isIdentStart(s[0]) {
+		return 0, ErrInvalidIdent
+	}
+	i := 1
+	for i < len(s) && isIdentChar(s[i]) {
+		i++
+	}
+	return i, nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || c == '$' || ('0' <= c && c <= '9')
+}
@@ -0,0 +1,94 @@
+package pqcomp_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/piotrkowalczuk/pqcomp"
+)
+
+func TestQuoteIdent(t *testing.T) {
+	success := map[string]string{
+		"id":                   `"id"`,
+		"u.first_name":         `"u"."first_name"`,
+		"public.user.id":       `"public"."user"."id"`,
+		`u."First Name"`:       `"u"."First Name"`,
+		`"a""b".c1`:            `"a""b"."c1"`,
+		"_private.column_$1":   `"_private"."column_$1"`,
+		"UPPER.Case":           `"upper"."case"`,
+		"u.firstName":          `"u"."firstname"`,
+		`u."firstName"`:        `"u"."firstName"`,
+		"u.first_name_2000_$x": `"u"."first_name_2000_$x"`,
+	}
+
+	for key, expected := range success {
+		got, err := pqcomp.QuoteIdent(key)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", key, err.Error())
+			continue
+		}
+		if got != expected {
+			t.Errorf("%s: wrong quoted identifier, expected %s but got %s", key, expected, got)
+		}
+		if !pqcomp.ValidIdent(key) {
+			t.Errorf("%s: identifier should be valid", key)
+		}
+	}
+
+	failure := []string{
+		"",
+		".",
+		"u.",
+		".id",
+		"a.b.c.d",
+		"1id",
+		"id; DROP TABLE users",
+		"id--",
+		"u.id = 1 OR 1",
+		`"unterminated`,
+		`""`,
+		`"a"b`,
+		"lower(name)",
+		"$1",
+	}
+
+	for _, key := range failure {
+		if _, err := pqcomp.QuoteIdent(key); !errors.Is(err, pqcomp.ErrInvalidIdent) {
+			t.Errorf("%s: wrong error, expected %v but got %v", key, pqcomp.ErrInvalidIdent, err)
+		}
+		if pqcomp.ValidIdent(key) {
+			t.Errorf("%s: identifier should be invalid", key)
+		}
+	}
+}
+
+func TestComposer_SetStrict(t *testing.T) {
+	comp := pqcomp.New(0, 0, 1).SetStrict(true)
+	where := comp.Compose()
+	where.AddExpr("u.first_name", pqcomp.Equal, "john")
+	where.AddExpr("1=1 OR u.id", pqcomp.Equal, 1)
+	where.AddExpr("u.id", pqcomp.In, []int64{1, 2})
+
+	expected := `WHERE "u"."first_name" = $1 AND "u"."id" IN ($2, $3)`
+	if got := where.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+	if len(comp.Args()) != 3 {
+		t.Errorf("wrong number of arguments, expected %d but got %d", 3, len(comp.Args()))
+	}
+	if err := comp.Err(); !errors.Is(err, pqcomp.ErrInvalidIdent) {
+		t.Errorf("wrong error, expected %v but got %v", pqcomp.ErrInvalidIdent, err)
+	}
+}
+
+func TestComposer_Err(t *testing.T) {
+	comp := pqcomp.New(0, 0)
+	comp.AddExpr("1=1 OR u.id", pqcomp.Equal, 1)
+
+	if err := comp.Err(); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if comp.Len() != 1 {
+		t.Errorf("wrong number of expressions, expected %d but got %d", 1, comp.Len())
+	}
+}
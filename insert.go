@@ -0,0 +1,312 @@
+package pqcomp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	conflictNothing = "NOTHING"
+	conflictUpdate  = "UPDATE"
+)
+
+// Insert builds INSERT statement. Values can be collected either column by column for single row,
+// optionally skipping absent ones the same way as AddExpr does, or row by row for declared list of columns.
+// PostgreSQL ON CONFLICT clause is supported as well.
+type Insert struct {
+	root, values, update, where *Composer
+	table                       string
+	columns                     []string
+	returning                   []string
+	conflict, constraint        []string
+	action                      string
+	excluded                    []string
+	excludedAll                 bool
+}
+
+// NewInsert allocates new INSERT statement builder for given table.
+// Columns are required only if rows are added using Values.
+func NewInsert(table string, columns ...string) *Insert {
+	root := New(0, 0, 0, 0, 0)
+	ins := &Insert{
+		root:    root,
+		values:  root.Compose(),
+		update:  root.Compose(),
+		where:   root.Compose(),
+		table:   table,
+		columns: columns,
+	}
+	ins.update.assignment = true
+	return ins
+}
+
+// Composer returns root composer of the statement. It can be used to set placeholder format using SetFormat,
+// policy or strict mode.
+func (i *Insert) Composer() *Composer {
+	return i.root
+}
+
+// Add adds column and its value to the single row, value is always used.
+func (i *Insert) Add(column string, value interface{}) *Insert {
+	Add(i.values, column, Equal, value, nil)
+	return i
+}
+
+// AddExpr adds column and its value to the single row if value meet the same requirements as in Composer.AddExpr.
+// Unlike Composer.AddExpr, slice is bound as single value.
+func (i *Insert) AddExpr(column string, value interface{}) *Insert {
+	if value = indirect(value); i.values.currentPolicy()(value) {
+		Add(i.values, column, Equal, value, nil)
+	}
+	return i
+}
+
+// Values adds row of values, one for each column passed to NewInsert.
+// It returns an error if no columns were passed to NewInsert.
+func (i *Insert) Values(values ...interface{}) error {
+	if len(i.columns) == 0 {
+		return errors.New("pqcomp: columns are required to add rows")
+	}
+	if len(values) != len(i.columns) {
+		return fmt.Errorf("%w: expected %d values but got %d", ErrArity, len(i.columns), len(values))
+	}
+
+	row := i.values.Compose()
+	for _, v := range values {
+		row.AddArg(v)
+	}
+	return nil
+}
+
+// OnConflict sets columns of ON CONFLICT clause conflict target.
+func (i *Insert) OnConflict(columns ...string) *Insert {
+	i.conflict, i.constraint = columns, nil
+	return i
+}
+
+// OnConstraint sets constraint name as ON CONFLICT clause conflict target.
+// In strict mode it's validated and quoted the same way as columns.
+func (i *Insert) OnConstraint(name string) *Insert {
+	i.conflict, i.constraint = nil, []string{name}
+	return i
+}
+
+// DoNothing sets DO NOTHING as conflict action. If both DoNothing and any of DoUpdate methods are called, last call wins,
+// but if composers returned by DoUpdate or DoUpdateWhere are not empty, ToSQL returns an error.
+func (i *Insert) DoNothing() *Insert {
+	i.action = conflictNothing
+	return i
+}
+
+// DoUpdate sets DO UPDATE as conflict action and returns composer that collects its SET clause.
+// Only Equal operator is allowed, the same way as in Composer.Set. Slice passed to its AddExpr is bound as single value.
+func (i *Insert) DoUpdate() *Composer {
+	i.action = conflictUpdate
+	return i.update
+}
+
+// DoUpdateExcluded sets DO UPDATE as conflict action and adds column = EXCLUDED.column to its SET clause
+// for each given column. If no columns are given, all inserted columns except the conflict target are used.
+func (i *Insert) DoUpdateExcluded(columns ...string) *Insert {
+	i.action = conflictUpdate
+	if len(columns) == 0 {
+		i.excludedAll = true
+	}
+	i.excluded = append(i.excluded, columns...)
+	return i
+}
+
+// DoUpdateWhere returns composer of WHERE clause of DO UPDATE conflict action.
+// It does not set the action, if it's used without DO UPDATE, ToSQL returns an error.
+func (i *Insert) DoUpdateWhere() *Composer {
+	return i.where
+}
+
+// Returning adds columns to RETURNING clause.
+func (i *Insert) Returning(columns ...string) *Insert {
+	i.returning = append(i.returning, columns...)
+	return i
+}
+
+// ToSQL renders whole statement and returns it together with arguments in order matching placeholders.
+// If single row has no values, DEFAULT VALUES are inserted.
+// It returns the first error recorded by any of the composers.
+func (i *Insert) ToSQL() (string, []interface{}, error) {
+	if err := i.root.Err(); err != nil {
+		return "", nil, err
+	}
+	if i.values.Len() > 0 && len(i.values.childs) > 0 {
+		return "", nil, errors.New("pqcomp: single row values cannot be mixed with rows")
+	}
+
+	var buf strings.Builder
+	buf.WriteString("INSERT INTO ")
+	buf.WriteString(i.table)
+
+	var columns []string
+	switch {
+	case len(i.values.childs) > 0:
+		var err error
+		if columns, err = i.quote(i.columns); err != nil {
+			return "", nil, err
+		}
+
+		buf.WriteString(" (")
+		buf.WriteString(strings.Join(columns, ", "))
+		buf.WriteString(") VALUES ")
+		// rows have no children, so their arguments follow each other
+		n := i.values.offset() + len(i.values.arguments)
+		for r, row := range i.values.childs {
+			if r > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString("(")
+			for j := range row.arguments {
+				if j > 0 {
+					buf.WriteString(", ")
+				}
+				n++
+				buf.WriteString(row.placeHolder(n))
+			}
+			buf.WriteString(")")
+		}
+	case i.values.Len() > 0:
+		var placeholders strings.Builder
+		buf.WriteString(" (")
+		i.values.Reset()
+		for i.values.Next() {
+			if !i.values.First() {
+				buf.WriteString(", ")
+				placeholders.WriteString(", ")
+			}
+			columns = append(columns, i.values.Key())
+			buf.WriteString(i.values.Key())
+			placeholders.WriteString(i.values.PlaceHolder())
+		}
+		i.values.Reset()
+		buf.WriteString(") VALUES (")
+		buf.WriteString(placeholders.String())
+		buf.WriteString(")")
+	default:
+		buf.WriteString(" DEFAULT VALUES")
+	}
+
+	if err := i.onConflict(&buf, columns); err != nil {
+		return "", nil, err
+	}
+
+	if len(i.returning) > 0 {
+		buf.WriteString(" RETURNING ")
+		buf.WriteString(strings.Join(i.returning, ", "))
+	}
+
+	return buf.String(), i.root.Args(), nil
+}
+
+func (i *Insert) onConflict(buf *strings.Builder, columns []string) error {
+	if i.action != conflictUpdate {
+		for _, c := range []*Composer{i.update, i.where} {
+			if !c.empty() || c.lenWithChilds() > 0 {
+				return errors.New("pqcomp: DO UPDATE clauses are set, but conflict action is not DO UPDATE")
+			}
+		}
+	}
+	if i.action == "" {
+		if len(i.conflict) > 0 || len(i.constraint) > 0 {
+			return errors.New("pqcomp: conflict action is missing")
+		}
+		return nil
+	}
+
+	conflict, err := i.quote(i.conflict)
+	if err != nil {
+		return err
+	}
+	constraint, err := i.quote(i.constraint)
+	if err != nil {
+		return err
+	}
+
+	buf.WriteString(" ON CONFLICT")
+	switch {
+	case len(conflict) > 0:
+		buf.WriteString(" (")
+		buf.WriteString(strings.Join(conflict, ", "))
+		buf.WriteString(")")
+	case len(constraint) > 0:
+		buf.WriteString(" ON CONSTRAINT ")
+		buf.WriteString(constraint[0])
+	case i.action == conflictUpdate:
+		return errors.New("pqcomp: DO UPDATE requires conflict target")
+	}
+
+	if i.action == conflictNothing {
+		buf.WriteString(" DO NOTHING")
+		return nil
+	}
+
+	var sets []string
+	if i.update.Len() > 0 {
+		set, err := i.update.Set()
+		if err != nil {
+			return err
+		}
+		sets = append(sets, strings.TrimPrefix(set, "SET "))
+	}
+
+	excluded, err := i.quote(i.excluded)
+	if err != nil {
+		return err
+	}
+	if i.excludedAll {
+	ColumnsLoop:
+		for _, col := range columns {
+			for _, c := range conflict {
+				if sameIdent(c, col) {
+					continue ColumnsLoop
+				}
+			}
+			excluded = append(excluded, col)
+		}
+	}
+	for _, col := range excluded {
+		sets = append(sets, col+" = EXCLUDED."+col)
+	}
+	if len(sets) == 0 {
+		return ErrNothingToUpdate
+	}
+
+	buf.WriteString(" DO UPDATE SET ")
+	buf.WriteString(strings.Join(sets, ", "))
+	writeClause(buf, i.where.Where())
+	return nil
+}
+
+// sameIdent returns true if both identifiers refer to the same column, regardless if they were quoted.
+// Single row keys are quoted when added, conflict target when rendered, so strict mode could differ in between.
+func sameIdent(a, b string) bool {
+	qa, erra := QuoteIdent(a)
+	qb, errb := QuoteIdent(b)
+	if erra != nil || errb != nil {
+		return a == b
+	}
+	return qa == qb
+}
+
+// quote quotes columns in strict mode, otherwise returns them as they are.
+func (i *Insert) quote(columns []string) ([]string, error) {
+	if !i.root.isStrict() {
+		return columns, nil
+	}
+
+	quoted := make([]string, 0, len(columns))
+	for _, col := range columns {
+		q, err := QuoteIdent(col)
+		if err != nil {
+			return nil, err
+		}
+		quoted = append(quoted, q)
+	}
+	return quoted, nil
+}
@@ -0,0 +1,274 @@
+package pqcomp_test
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/piotrkowalczuk/pqcomp"
+)
+
+func TestInsert_ToSQL(t *testing.T) {
+	ins := pqcomp.NewInsert("users").
+		Add("username", "johnsnow").
+		AddExpr("first_name", "John").
+		AddExpr("last_name", &sql.NullString{}).
+		Add("age", nil).
+		Returning("id", "created_at")
+
+	query, args, err := ins.ToSQL()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedQuery := "INSERT INTO users (username, first_name, age) VALUES ($1, $2, $3) RETURNING id, created_at"
+	if query != expectedQuery {
+		t.Errorf("wrong query, expected %q but got %q", expectedQuery, query)
+	}
+	expectedArgs := []interface{}{"johnsnow", "John", nil}
+	if !reflect.DeepEqual(expectedArgs, args) {
+		t.Errorf("wrong arguments, expected %v but got %v", expectedArgs, args)
+	}
+}
+
+func TestInsert_Values(t *testing.T) {
+	ins := pqcomp.NewInsert("users", "username", "age")
+	ins.Composer().SetStrict(true)
+	if err := ins.Values("johnsnow", 20); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if err := ins.Values("aryastark", 12); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if err := ins.Values("sansastark"); !errors.Is(err, pqcomp.ErrArity) {
+		t.Errorf("wrong error, expected %v but got %v", pqcomp.ErrArity, err)
+	}
+
+	query, args, err := ins.ToSQL()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedQuery := `INSERT INTO users ("username", "age") VALUES ($1, $2), ($3, $4)`
+	if query != expectedQuery {
+		t.Errorf("wrong query, expected %q but got %q", expectedQuery, query)
+	}
+	expectedArgs := []interface{}{"johnsnow", 20, "aryastark", 12}
+	if !reflect.DeepEqual(expectedArgs, args) {
+		t.Errorf("wrong arguments, expected %v but got %v", expectedArgs, args)
+	}
+}
+
+func TestInsert_Values_noColumns(t *testing.T) {
+	if err := pqcomp.NewInsert("users").Values("johnsnow"); err == nil {
+		t.Errorf("expected error")
+	}
+	if err := pqcomp.NewInsert("users").Values(); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestInsert_ToSQL_format(t *testing.T) {
+	ins := pqcomp.NewInsert("users").
+		AddExpr("username", "johnsnow").
+		AddExpr("tags", []string{"a", "b"}).
+		AddExpr("groups", []int64(nil)).
+		OnConflict("username")
+	ins.Composer().SetFormat(pqcomp.Question)
+	ins.DoUpdate().AddExpr("tags", pqcomp.Equal, []string{"c", "d"})
+
+	query, args, err := ins.ToSQL()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedQuery := "INSERT INTO users (username, tags) VALUES (?, ?) ON CONFLICT (username) DO UPDATE SET tags = ?"
+	if query != expectedQuery {
+		t.Errorf("wrong query, expected %q but got %q", expectedQuery, query)
+	}
+	expectedArgs := []interface{}{"johnsnow", []string{"a", "b"}, []string{"c", "d"}}
+	if !reflect.DeepEqual(expectedArgs, args) {
+		t.Errorf("wrong arguments, expected %v but got %v", expectedArgs, args)
+	}
+}
+
+func TestInsert_ToSQL_defaultValues(t *testing.T) {
+	query, args, err := pqcomp.NewInsert("users").AddExpr("age", &sql.NullInt64{}).Returning("id").ToSQL()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := "INSERT INTO users DEFAULT VALUES RETURNING id"; query != expected {
+		t.Errorf("wrong query, expected %q but got %q", expected, query)
+	}
+	if len(args) != 0 {
+		t.Errorf("unexpected arguments: %v", args)
+	}
+}
+
+func TestInsert_ToSQL_mixed(t *testing.T) {
+	ins := pqcomp.NewInsert("users", "username").Add("age", 1)
+	if err := ins.Values("johnsnow"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if _, _, err := ins.ToSQL(); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestInsert_OnConflict(t *testing.T) {
+	cases := map[string]struct {
+		build func() *pqcomp.Insert
+		query string
+		args  []interface{}
+		fails bool
+		err   error
+	}{
+		"do-nothing": {
+			build: func() *pqcomp.Insert {
+				return pqcomp.NewInsert("users").Add("id", 1).Add("username", "johnsnow").OnConflict("id").DoNothing()
+			},
+			query: "INSERT INTO users (id, username) VALUES ($1, $2) ON CONFLICT (id) DO NOTHING",
+			args:  []interface{}{1, "johnsnow"},
+		},
+		"do-nothing-without-target": {
+			build: func() *pqcomp.Insert {
+				return pqcomp.NewInsert("users").Add("id", 1).DoNothing()
+			},
+			query: "INSERT INTO users (id) VALUES ($1) ON CONFLICT DO NOTHING",
+			args:  []interface{}{1},
+		},
+		"do-update-excluded-all": {
+			build: func() *pqcomp.Insert {
+				ins := pqcomp.NewInsert("users").
+					Add("id", 1).
+					AddExpr("username", "johnsnow").
+					AddExpr("first_name", &sql.NullString{}).
+					AddExpr("last_name", "Snow").
+					OnConflict("id").
+					DoUpdateExcluded().
+					Returning("id")
+				ins.DoUpdateWhere().AddExpr("users.locked", pqcomp.Equal, false)
+				return ins
+			},
+			query: "INSERT INTO users (id, username, last_name) VALUES ($1, $2, $3) " +
+				"ON CONFLICT (id) DO UPDATE SET username = EXCLUDED.username, last_name = EXCLUDED.last_name " +
+				"WHERE users.locked = $4 RETURNING id",
+			args: []interface{}{1, "johnsnow", "Snow", false},
+		},
+		"do-update-composer": {
+			build: func() *pqcomp.Insert {
+				ins := pqcomp.NewInsert("counters", "name", "value")
+				ins.Values("visits", 1)
+				ins.Values("clicks", 1)
+				ins.OnConstraint("counters_name_key").DoUpdateExcluded("value")
+				ins.DoUpdate().AddExpr("updated_at", pqcomp.Equal, "now")
+				return ins
+			},
+			query: "INSERT INTO counters (name, value) VALUES ($1, $2), ($3, $4) " +
+				"ON CONFLICT ON CONSTRAINT counters_name_key DO UPDATE SET updated_at = $5, value = EXCLUDED.value",
+			args: []interface{}{"visits", 1, "clicks", 1, "now"},
+		},
+		"do-update-strict": {
+			build: func() *pqcomp.Insert {
+				ins := pqcomp.NewInsert("users")
+				ins.Composer().SetStrict(true)
+				return ins.Add("id", 1).Add("username", "johnsnow").OnConflict("id").DoUpdateExcluded()
+			},
+			query: `INSERT INTO users ("id", "username") VALUES ($1, $2) ON CONFLICT ("id") DO UPDATE SET "username" = EXCLUDED."username"`,
+			args:  []interface{}{1, "johnsnow"},
+		},
+		"do-nothing-on-constraint-strict": {
+			build: func() *pqcomp.Insert {
+				ins := pqcomp.NewInsert("users")
+				ins.Composer().SetStrict(true)
+				return ins.Add("id", 1).OnConstraint("users_pkey").DoNothing()
+			},
+			query: `INSERT INTO users ("id") VALUES ($1) ON CONFLICT ON CONSTRAINT "users_pkey" DO NOTHING`,
+			args:  []interface{}{1},
+		},
+		"on-constraint-invalid-strict": {
+			build: func() *pqcomp.Insert {
+				ins := pqcomp.NewInsert("users")
+				ins.Composer().SetStrict(true)
+				return ins.Add("id", 1).OnConstraint("x; DROP TABLE t").DoNothing()
+			},
+			fails: true,
+			err:   pqcomp.ErrInvalidIdent,
+		},
+		"do-update-excluded-all-strict-late": {
+			build: func() *pqcomp.Insert {
+				ins := pqcomp.NewInsert("t").AddExpr("a", 1).AddExpr("b", 2)
+				ins.Composer().SetStrict(true)
+				return ins.OnConflict("a").DoUpdateExcluded()
+			},
+			query: `INSERT INTO t (a, b) VALUES ($1, $2) ON CONFLICT ("a") DO UPDATE SET b = EXCLUDED.b`,
+			args:  []interface{}{1, 2},
+		},
+		"do-update-without-target": {
+			build: func() *pqcomp.Insert {
+				return pqcomp.NewInsert("users").Add("id", 1).DoUpdateExcluded()
+			},
+			fails: true,
+		},
+		"do-update-nothing-to-update": {
+			build: func() *pqcomp.Insert {
+				return pqcomp.NewInsert("users").Add("id", 1).OnConflict("id").DoUpdateExcluded()
+			},
+			fails: true,
+			err:   pqcomp.ErrNothingToUpdate,
+		},
+		"do-update-overridden-by-do-nothing": {
+			build: func() *pqcomp.Insert {
+				ins := pqcomp.NewInsert("users").Add("id", 1).OnConflict("id")
+				ins.DoUpdate().AddExpr("username", pqcomp.Equal, "johnsnow")
+				return ins.DoNothing()
+			},
+			fails: true,
+		},
+		"do-update-excluded-overridden-by-do-nothing": {
+			build: func() *pqcomp.Insert {
+				return pqcomp.NewInsert("users").Add("id", 1).Add("username", "johnsnow").
+					OnConflict("id").DoUpdateExcluded().DoNothing()
+			},
+			query: "INSERT INTO users (id, username) VALUES ($1, $2) ON CONFLICT (id) DO NOTHING",
+			args:  []interface{}{1, "johnsnow"},
+		},
+		"do-update-where-without-action": {
+			build: func() *pqcomp.Insert {
+				ins := pqcomp.NewInsert("users").Add("id", 1)
+				ins.DoUpdateWhere().AddExpr("users.locked", pqcomp.Equal, false)
+				return ins
+			},
+			fails: true,
+		},
+		"target-without-action": {
+			build: func() *pqcomp.Insert {
+				return pqcomp.NewInsert("users").Add("id", 1).OnConflict("id")
+			},
+			fails: true,
+		},
+	}
+
+	for hint, c := range cases {
+		query, args, err := c.build().ToSQL()
+		if c.fails {
+			if err == nil {
+				t.Errorf("%s: expected error", hint)
+			} else if c.err != nil && !errors.Is(err, c.err) {
+				t.Errorf("%s: wrong error, expected %v but got %v", hint, c.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if query != c.query {
+			t.Errorf("%s: wrong query, expected:\n%s\nbut got:\n%s", hint, c.query, query)
+		}
+		if !reflect.DeepEqual(c.args, args) {
+			t.Errorf("%s: wrong arguments, expected %v but got %v", hint, c.args, args)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package pqcomp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCursor is returned if cursor token is malformed or does not match sort columns.
+var ErrCursor = errors.New("pqcomp: invalid cursor")
+
+// Seek adds keyset pagination predicate and ordering to the composer.
+// Columns are trusted list of columns that together uniquely identify a row, e.g. "u.created_at", "u.id".
+// Cursor holds values of those columns for the last row of previous page, if it's empty only ordering is added.
+// For Descendant direction it produces (u.created_at, u.id) < ($1, $2) predicate, for Ascendant one the greater than operator is used.
+// Ordering is added to the builder returned by OrderBy. In strict mode columns are validated and quoted.
+func (c *Composer) Seek(columns []string, direction string, cursor ...interface{}) error {
+	if len(columns) == 0 {
+		return errors.New("pqcomp: keyset pagination requires at least one column")
+	}
+	if len(cursor) != 0 && len(cursor) != len(columns) {
+		return fmt.Errorf("%w: expected %d values but got %d", ErrCursor, len(columns), len(cursor))
+	}
+
+	var operator string
+	switch strings.ToUpper(direction) {
+	case Ascendant:
+		direction, operator = Ascendant, GreaterThan
+	case Descendant:
+		direction, operator = Descendant, LessThan
+	default:
+		return fmt.Errorf("pqcomp: unknown sort direction %q", direction)
+	}
+
+	cols := make([]string, 0, len(columns))
+	for _, col := range columns {
+		if c.isStrict() {
+			quoted, err := QuoteIdent(col)
+			if err != nil {
+				return err
+			}
+			col = quoted
+		}
+		cols = append(cols, col)
+	}
+
+	order := c.OrderBy(nil)
+	for _, col := range cols {
+		order.keys = append(order.keys, col+" "+direction)
+	}
+
+	if len(cursor) == 0 {
+		return nil
+	}
+	if len(cols) == 1 {
+		c.register(cols[0], operator, 1)
+	} else {
+		c.register("("+strings.Join(cols, ", ")+")", operator, len(cols))
+	}
+	c.arguments = append(c.arguments, cursor...)
+
+	return nil
+}
+
+// EncodeCursor encodes values of the last row into opaque token that can be passed to API clients.
+func EncodeCursor(values ...interface{}) (string, error) {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeCursor decodes token produced by EncodeCursor into given pointers, one for each encoded value.
+// Empty token decodes nothing, which means first page.
+func DecodeCursor(token string, dst ...interface{}) error {
+	if token == "" {
+		return nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrCursor, err.Error())
+	}
+	var values []json.RawMessage
+	if err := json.Unmarshal(b, &values); err != nil {
+		return fmt.Errorf("%w: %s", ErrCursor, err.Error())
+	}
+	if len(values) != len(dst) {
+		return fmt.Errorf("%w: expected %d values but got %d", ErrCursor, len(dst), len(values))
+	}
+	for i, v := range values {
+		if err := json.Unmarshal(v, dst[i]); err != nil {
+			return fmt.Errorf("%w: %s", ErrCursor, err.Error())
+		}
+	}
+
+	return nil
+}
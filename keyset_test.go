@@ -0,0 +1,125 @@
+package pqcomp_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/pqcomp"
+)
+
+func TestComposer_Seek(t *testing.T) {
+	createdAt := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		columns      []string
+		direction    string
+		cursor       []interface{}
+		where, order string
+		args         []interface{}
+	}{
+		"first-page": {
+			columns:   []string{"u.created_at", "u.id"},
+			direction: pqcomp.Descendant,
+			where:     "WHERE u.active = $1",
+			order:     "ORDER BY u.created_at DESC, u.id DESC",
+			args:      []interface{}{true},
+		},
+		"descendant": {
+			columns:   []string{"u.created_at", "u.id"},
+			direction: pqcomp.Descendant,
+			cursor:    []interface{}{createdAt, int64(10)},
+			where:     "WHERE u.active = $1 AND (u.created_at, u.id) < ($2, $3)",
+			order:     "ORDER BY u.created_at DESC, u.id DESC",
+			args:      []interface{}{true, createdAt, int64(10)},
+		},
+		"ascendant-single": {
+			columns:   []string{"u.id"},
+			direction: "asc",
+			cursor:    []interface{}{int64(10)},
+			where:     "WHERE u.active = $1 AND u.id > $2",
+			order:     "ORDER BY u.id ASC",
+			args:      []interface{}{true, int64(10)},
+		},
+	}
+
+	for hint, c := range cases {
+		comp := pqcomp.New(0, 0)
+		comp.AddExpr("u.active", pqcomp.Equal, true)
+		if err := comp.Seek(c.columns, c.direction, c.cursor...); err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+
+		if got := comp.Where(); got != c.where {
+			t.Errorf("%s: wrong where clause, expected %q but got %q", hint, c.where, got)
+		}
+		if got := comp.OrderBy(nil).String(); got != c.order {
+			t.Errorf("%s: wrong order clause, expected %q but got %q", hint, c.order, got)
+		}
+		if got := comp.Args(); !reflect.DeepEqual(c.args, got) {
+			t.Errorf("%s: wrong arguments, expected %v but got %v", hint, c.args, got)
+		}
+	}
+}
+
+func TestComposer_Seek_strict(t *testing.T) {
+	comp := pqcomp.New(0, 0).SetStrict(true)
+	if err := comp.Seek([]string{"u.created_at", "u.id"}, pqcomp.Descendant, 1, 2); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := `WHERE ("u"."created_at", "u"."id") < ($1, $2)`
+	if got := comp.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+	if err := comp.Seek([]string{"u.id; --"}, pqcomp.Descendant); !errors.Is(err, pqcomp.ErrInvalidIdent) {
+		t.Errorf("wrong error, expected %v but got %v", pqcomp.ErrInvalidIdent, err)
+	}
+}
+
+func TestComposer_Seek_invalid(t *testing.T) {
+	comp := pqcomp.New(0, 0)
+	if err := comp.Seek([]string{"a", "b"}, pqcomp.Descendant, 1); !errors.Is(err, pqcomp.ErrCursor) {
+		t.Errorf("wrong error, expected %v but got %v", pqcomp.ErrCursor, err)
+	}
+	if err := comp.Seek([]string{"a"}, "sideways", 1); err == nil {
+		t.Errorf("expected error")
+	}
+	if comp.Len() != 0 {
+		t.Errorf("nothing should be added, got %d expressions", comp.Len())
+	}
+}
+
+func TestEncodeCursor(t *testing.T) {
+	createdAt := time.Date(2016, 1, 1, 12, 30, 0, 0, time.UTC)
+
+	token, err := pqcomp.EncodeCursor(createdAt, int64(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	var (
+		gotCreatedAt time.Time
+		gotID        int64
+	)
+	if err := pqcomp.DecodeCursor(token, &gotCreatedAt, &gotID); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !gotCreatedAt.Equal(createdAt) || gotID != 10 {
+		t.Errorf("wrong decoded values, got %v and %d", gotCreatedAt, gotID)
+	}
+
+	if err := pqcomp.DecodeCursor("", &gotID); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	for _, token := range []string{"!!!", "bm90LWpzb24", token[:len(token)-2]} {
+		if err := pqcomp.DecodeCursor(token, &gotCreatedAt, &gotID); !errors.Is(err, pqcomp.ErrCursor) {
+			t.Errorf("%s: wrong error, expected %v but got %v", token, pqcomp.ErrCursor, err)
+		}
+	}
+	if err := pqcomp.DecodeCursor(token, &gotID); !errors.Is(err, pqcomp.ErrCursor) {
+		t.Errorf("wrong error, expected %v but got %v", pqcomp.ErrCursor, err)
+	}
+}
@@ -0,0 +1,149 @@
+package pqcomp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// List can be used as an Operator arity, it means that operator binds each element of slice value as separate argument.
+const List = -1
+
+var (
+	// ErrUnknownOperator is recorded by the composer with operator validation enabled if operator is not registered.
+	ErrUnknownOperator = errors.New("pqcomp: unknown operator")
+	// ErrArity is recorded by the composer if number of arguments does not match arity of the operator.
+	ErrArity = errors.New("pqcomp: wrong number of arguments")
+)
+
+// Operator describes how expression that use it binds and renders arguments.
+type Operator struct {
+	// Name is an operator as it is passed to AddExpr, e.g. "&&".
+	Name string
+	// Arity is a number of arguments that expression binds, zero for unary operators or List.
+	// If it's greater than one AddExpr expects slice of exactly that length.
+	Arity int
+	// Array is true if slice value should be bound as single argument,
+	// otherwise each element of the slice produces separate expression.
+	Array bool
+	// Render renders placeholders of single expression, it's what PlaceHolder returns.
+	// If nil, single placeholder is rendered as it is, multiple ones are joined by comma and parenthesized.
+	Render func(placeholders []string) string
+}
+
+var operators = struct {
+	sync.RWMutex
+	m map[string]Operator
+}{
+	m: make(map[string]Operator),
+}
+
+func init() {
+	for _, name := range []string{
+		Like, Is, Equal, NotEqual,
+		GreaterThan, LessThan, GreaterThanOrEqual, LessThanOrEqual,
+		Contains, IsContainedBy, Overlap, Exists, ExistsAny, ExistsAll,
+	} {
+		operators.m[name] = Operator{Name: name, Arity: 1}
+	}
+	for _, op := range []Operator{
+		{Name: IsNull, Arity: 0},
+		{Name: IsNotNull, Arity: 0},
+		{Name: In, Arity: List, Render: renderList},
+		{Name: NotIn, Arity: List, Render: renderList},
+		{Name: Between, Arity: 2, Render: renderPair},
+		{Name: NotBetween, Arity: 2, Render: renderPair},
+		{Name: EqualAny, Arity: 1, Array: true, Render: renderList},
+		{Name: NotEqualAll, Arity: 1, Array: true, Render: renderList},
+	} {
+		operators.m[op.Name] = op
+	}
+}
+
+// RegisterOperator registers custom operator, so it passes validation and is rendered according to its description.
+// It returns an error if operator is already registered or its description is invalid.
+func RegisterOperator(op Operator) error {
+	if op.Name == "" {
+		return errors.New("pqcomp: operator name cannot be empty")
+	}
+	if op.Arity < 0 && op.Arity != List {
+		return fmt.Errorf("pqcomp: operator %q has invalid arity %d", op.Name, op.Arity)
+	}
+
+	operators.Lock()
+	defer operators.Unlock()
+
+	if _, ok := operators.m[op.Name]; ok {
+		return fmt.Errorf("pqcomp: operator %q is already registered", op.Name)
+	}
+	operators.m[op.Name] = op
+	return nil
+}
+
+// lookupOperator returns registered operator. If operator is not registered it returns a description with arity one,
+// that binds slices as single argument if it ends with ANY or ALL, and false.
+// Built-in binary operators are resolved without taking the lock.
+func lookupOperator(name string) (Operator, bool) {
+	if isBinary(name) {
+		return Operator{Name: name, Arity: 1}, true
+	}
+
+	operators.RLock()
+	op, ok := operators.m[name]
+	operators.RUnlock()
+	if ok {
+		return op, true
+	}
+
+	op = Operator{Name: name, Arity: 1}
+	if strings.HasSuffix(name, Any) || strings.HasSuffix(name, All) {
+		op.Array = true
+		op.Render = renderList
+	}
+	return op, false
+}
+
+// isBinary reports if operator is one of built-in operators that bind exactly one argument as it is.
+// Built-in operators cannot be re-registered, so it allows to skip the registry lookup and its lock.
+func isBinary(name string) bool {
+	switch name {
+	case Equal, NotEqual, GreaterThan, LessThan, GreaterThanOrEqual, LessThanOrEqual, Like, Is:
+		return true
+	}
+	return false
+}
+
+// SetOperatorValidation enables or disables operator validation. If enabled, expressions with operator
+// that is not registered are not added, instead an error is recorded and can be retrieved using Err.
+// Child composers inherit it from the parent.
+func (c *Composer) SetOperatorValidation(enabled bool) *Composer {
+	c.validate = enabled
+	return c
+}
+
+func (c *Composer) validatesOperators() bool {
+	for cc := c; cc != nil; cc = cc.parent {
+		if cc.validate {
+			return true
+		}
+	}
+	return false
+}
+
+// checkArity records an error and returns false if given number of arguments does not match the operator.
+func (c *Composer) checkArity(op Operator, n int) bool {
+	if op.Arity != List && op.Arity != n {
+		c.errs = append(c.errs, fmt.Errorf("%w: operator %q expects %d but got %d", ErrArity, op.Name, op.Arity, n))
+		return false
+	}
+	return true
+}
+
+func renderList(placeholders []string) string {
+	return "(" + strings.Join(placeholders, ", ") + ")"
+}
+
+func renderPair(placeholders []string) string {
+	return placeholders[0] + " AND " + placeholders[1]
+}
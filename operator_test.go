@@ -0,0 +1,144 @@
+package pqcomp_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/piotrkowalczuk/pqcomp"
+)
+
+func init() {
+	ops := []pqcomp.Operator{
+		{Name: "&&", Arity: 1, Array: true},
+		{Name: "<->", Arity: 1},
+		{Name: "~=", Arity: 2, Render: func(placeholders []string) string {
+			return "point(" + placeholders[0] + ", " + placeholders[1] + ")"
+		}},
+	}
+	for _, op := range ops {
+		if err := pqcomp.RegisterOperator(op); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func TestRegisterOperator(t *testing.T) {
+	cases := map[string]pqcomp.Operator{
+		"duplicate":     {Name: pqcomp.Equal, Arity: 1},
+		"empty-name":    {Arity: 1},
+		"invalid-arity": {Name: "!!", Arity: -2},
+	}
+
+	for hint, op := range cases {
+		if err := pqcomp.RegisterOperator(op); err == nil {
+			t.Errorf("%s: expected error", hint)
+		}
+	}
+}
+
+func TestComposer_SetOperatorValidation(t *testing.T) {
+	comp := pqcomp.New(0, 0, 1).SetOperatorValidation(true)
+	where := comp.Compose()
+	where.AddExpr("a", pqcomp.Equal, 1)
+	where.AddExpr("b", "= 1 OR 1 =", 2)
+	where.AddExpr("c", "&&", []string{"x", "y"})
+	where.AddExpr("d", "<->", "point")
+	where.AddExpr("e", "~=", []float64{1.1, 2.2})
+
+	expected := "WHERE a = $1 AND c && $2 AND d <-> $3 AND e ~= point($4, $5)"
+	if got := where.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+	if err := comp.Err(); !errors.Is(err, pqcomp.ErrUnknownOperator) {
+		t.Errorf("wrong error, expected %v but got %v", pqcomp.ErrUnknownOperator, err)
+	}
+}
+
+func TestComposer_AddExpr_unary(t *testing.T) {
+	comp := pqcomp.New(0, 0)
+	comp.AddExpr("a", pqcomp.Equal, 1)
+	comp.AddExpr("b", pqcomp.IsNull, pqcomp.Empty)
+	comp.AddExpr("c", pqcomp.IsNotNull, true)
+	comp.AddExpr("d", pqcomp.IsNull, nil)
+	pqcomp.Add(comp, "f", pqcomp.IsNotNull, true, nil)
+	comp.AddUnary("g", pqcomp.IsNull)
+	comp.AddExpr("e", pqcomp.Equal, 2)
+
+	expected := "WHERE a = $1 AND b IS NULL AND c IS NOT NULL AND f IS NOT NULL AND g IS NULL AND e = $2"
+	if got := comp.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+	if len(comp.Args()) != 2 {
+		t.Errorf("wrong number of arguments, expected %d but got %d", 2, len(comp.Args()))
+	}
+
+	comp.Reset()
+	comp.Next()
+	comp.Next()
+	expr, err := comp.Expr()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expr.PlaceHolder != "" || expr.String() != "b IS NULL" {
+		t.Errorf("wrong unary expression: %q", expr.String())
+	}
+}
+
+func TestComposer_AddUnary(t *testing.T) {
+	comp := pqcomp.New(0, 0)
+	comp.AddUnary("deleted_at", pqcomp.IsNull)
+	comp.AddUnary("id", pqcomp.In)
+
+	expected := "WHERE deleted_at IS NULL"
+	if got := comp.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+	if len(comp.Args()) != 0 {
+		t.Errorf("wrong number of arguments, expected %d but got %d", 0, len(comp.Args()))
+	}
+	if err := comp.Err(); !errors.Is(err, pqcomp.ErrArity) {
+		t.Errorf("wrong error, expected %v but got %v", pqcomp.ErrArity, err)
+	}
+}
+
+func TestComposer_AddExpr_arity(t *testing.T) {
+	comp := pqcomp.New(0, 0)
+	comp.AddExpr("a", pqcomp.Between, []int64{1, 2})
+	comp.AddExpr("b", pqcomp.Between, []int64{1, 2, 3})
+
+	expected := "WHERE a BETWEEN $1 AND $2"
+	if got := comp.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+	if err := comp.Err(); !errors.Is(err, pqcomp.ErrArity) {
+		t.Errorf("wrong error, expected %v but got %v", pqcomp.ErrArity, err)
+	}
+}
+
+func TestComposer_AddExpr_arityScalar(t *testing.T) {
+	cases := map[string]func(*pqcomp.Composer){
+		"add-expr": func(c *pqcomp.Composer) { c.AddExpr("x", pqcomp.Between, 5) },
+		"add":      func(c *pqcomp.Composer) { pqcomp.Add(c, "x", pqcomp.Between, 5, nil) },
+		"bytes":    func(c *pqcomp.Composer) { c.AddExpr("x", "~=", []byte("a")) },
+		"struct": func(c *pqcomp.Composer) {
+			_ = c.AddStruct(struct {
+				X int `pqcomp:"x,BETWEEN"`
+			}{X: 5})
+		},
+	}
+
+	for hint, add := range cases {
+		comp := pqcomp.New(0, 0)
+		add(comp)
+
+		if got := comp.Where(); got != "" {
+			t.Errorf("%s: wrong where clause, expected empty but got %q", hint, got)
+		}
+		if got := comp.Args(); len(got) != 0 {
+			t.Errorf("%s: wrong args, expected none but got %v", hint, got)
+		}
+		if err := comp.Err(); !errors.Is(err, pqcomp.ErrArity) {
+			t.Errorf("%s: wrong error, expected %v but got %v", hint, pqcomp.ErrArity, err)
+		}
+	}
+}
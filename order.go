@@ -0,0 +1,114 @@
+package pqcomp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// NullsFirst represents NULLS FIRST keywords.
+	NullsFirst = "NULLS FIRST"
+	// NullsLast represents NULLS LAST keywords.
+	NullsLast = "NULLS LAST"
+)
+
+// ErrUnknownSortField is returned by Order if sort field is not whitelisted.
+var ErrUnknownSortField = errors.New("pqcomp: unknown sort field")
+
+// Order builds ORDER BY clause out of user supplied sort fields.
+// Each field is mapped through whitelist to a column and direction is limited to well known keywords,
+// so rendered clause never contains untrusted text.
+type Order struct {
+	columns map[string]string
+	keys    []string
+}
+
+// OrderBy returns ordering builder of the composer, it's created on first call.
+// Columns maps sort fields that can be supplied by the user to real columns, e.g. "name" to "u.first_name".
+// Subsequent calls extend the whitelist.
+func (c *Composer) OrderBy(columns map[string]string) *Order {
+	if c.order == nil {
+		c.order = &Order{columns: make(map[string]string, len(columns))}
+	}
+	for field, column := range columns {
+		c.order.columns[field] = column
+	}
+	return c.order
+}
+
+// Add adds sort key. Direction can be Ascendant, Descendant (case insensitive) or empty string for database default.
+func (o *Order) Add(field, direction string) error {
+	return o.AddNulls(field, direction, "")
+}
+
+// AddNulls works like Add, but additionally sets position of NULL values.
+// Nulls can be NullsFirst, NullsLast, "first", "last" (case insensitive) or empty string for database default.
+func (o *Order) AddNulls(field, direction, nulls string) error {
+	column, ok := o.columns[field]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownSortField, field)
+	}
+
+	key := column
+	switch strings.ToUpper(direction) {
+	case "":
+	case Ascendant:
+		key += " " + Ascendant
+	case Descendant:
+		key += " " + Descendant
+	default:
+		return fmt.Errorf("pqcomp: unknown sort direction %q", direction)
+	}
+	switch strings.ToUpper(nulls) {
+	case "":
+	case NullsFirst, "FIRST":
+		key += " " + NullsFirst
+	case NullsLast, "LAST":
+		key += " " + NullsLast
+	default:
+		return fmt.Errorf("pqcomp: unknown nulls position %q", nulls)
+	}
+
+	o.keys = append(o.keys, key)
+	return nil
+}
+
+// Parse adds sort keys from comma separated list of fields, like "name,-created_at".
+// Field prefixed by minus sign is sorted in descendant order, otherwise in ascendant order.
+// Nothing is added if any of the fields is not whitelisted.
+func (o *Order) Parse(sort string) error {
+	if sort == "" {
+		return nil
+	}
+
+	fields := strings.Split(sort, ",")
+	for _, field := range fields {
+		if _, ok := o.columns[strings.TrimPrefix(strings.TrimSpace(field), "-")]; !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownSortField, field)
+		}
+	}
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if strings.HasPrefix(field, "-") {
+			o.Add(field[1:], Descendant)
+		} else {
+			o.Add(field, Ascendant)
+		}
+	}
+
+	return nil
+}
+
+// Len returns number of sort keys.
+func (o *Order) Len() int {
+	return len(o.keys)
+}
+
+// String renders ORDER BY clause. It returns empty string if there are no sort keys.
+func (o *Order) String() string {
+	if len(o.keys) == 0 {
+		return ""
+	}
+	return "ORDER BY " + strings.Join(o.keys, ", ")
+}
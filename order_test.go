@@ -0,0 +1,70 @@
+package pqcomp_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/piotrkowalczuk/pqcomp"
+)
+
+var orderColumns = map[string]string{
+	"name":       "u.first_name",
+	"created_at": "u.created_at",
+	"id":         "u.id",
+}
+
+func TestOrder_Add(t *testing.T) {
+	comp := pqcomp.New(0, 0)
+	order := comp.OrderBy(orderColumns)
+
+	if got := order.String(); got != "" {
+		t.Errorf("empty order should render empty string, got %q", got)
+	}
+
+	steps := []struct {
+		field, direction, nulls string
+		err                     bool
+	}{
+		{field: "name", direction: "asc"},
+		{field: "created_at", direction: pqcomp.Descendant, nulls: "last"},
+		{field: "id", nulls: pqcomp.NullsFirst},
+		{field: "u.id; DROP TABLE users", direction: pqcomp.Ascendant, err: true},
+		{field: "id", direction: "DESC; DROP TABLE users", err: true},
+		{field: "id", nulls: "middle", err: true},
+	}
+	for _, s := range steps {
+		if err := order.AddNulls(s.field, s.direction, s.nulls); (err != nil) != s.err {
+			t.Errorf("%s: unexpected error: %v", s.field, err)
+		}
+	}
+
+	expected := "ORDER BY u.first_name ASC, u.created_at DESC NULLS LAST, u.id NULLS FIRST"
+	if got := order.String(); got != expected {
+		t.Errorf("wrong order clause, expected %q but got %q", expected, got)
+	}
+	if comp.OrderBy(nil) != order {
+		t.Errorf("composer should return the same ordering builder")
+	}
+}
+
+func TestOrder_Parse(t *testing.T) {
+	cases := map[string]struct {
+		sort, expected string
+		err            error
+	}{
+		"empty":    {sort: "", expected: ""},
+		"single":   {sort: "name", expected: "ORDER BY u.first_name ASC"},
+		"multiple": {sort: "-created_at, id", expected: "ORDER BY u.created_at DESC, u.id ASC"},
+		"unknown":  {sort: "name,password", expected: "", err: pqcomp.ErrUnknownSortField},
+	}
+
+	for hint, c := range cases {
+		order := pqcomp.New(0, 0).OrderBy(orderColumns)
+		if err := order.Parse(c.sort); !errors.Is(err, c.err) {
+			t.Errorf("%s: wrong error, expected %v but got %v", hint, c.err, err)
+		}
+		if got := order.String(); got != c.expected {
+			t.Errorf("%s: wrong order clause, expected %q but got %q", hint, c.expected, got)
+		}
+	}
+}
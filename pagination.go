@@ -0,0 +1,50 @@
+package pqcomp
+
+import "strings"
+
+// SetMaxLimit sets maximum page size enforced by Paginate. Zero means no limit.
+func (c *Composer) SetMaxLimit(max int64) *Composer {
+	c.maxLimit = max
+	return c
+}
+
+// Paginate adds limit and offset as arguments of the composer. Values lower than or equal to zero are skipped.
+// They are returned by Args after arguments of the composer and its descendants,
+// the same order as Pagination is rendered after the conditions, regardless of when Paginate was called.
+// If maximum page size is set, limit greater than it or absent one is replaced by the maximum.
+// If called more than once, the last call wins.
+func (c *Composer) Paginate(limit, offset int64) {
+	c.page, c.limitAt, c.offsetAt = c.page[:0], 0, 0
+	if c.maxLimit > 0 && (limit <= 0 || limit > c.maxLimit) {
+		limit = c.maxLimit
+	}
+
+	if limit > 0 {
+		c.page = append(c.page, limit)
+		c.limitAt = len(c.page)
+	}
+	if offset > 0 {
+		c.page = append(c.page, offset)
+		c.offsetAt = len(c.page)
+	}
+}
+
+// Pagination renders LIMIT and OFFSET clause for arguments added by Paginate.
+// Placeholders are numbered the same way as by PlaceHolder.
+// It returns empty string if neither limit nor offset is present.
+func (c *Composer) Pagination() string {
+	var buf strings.Builder
+	at := c.offset() + c.lenWithChilds() - len(c.page)
+	if c.limitAt > 0 {
+		buf.WriteString("LIMIT ")
+		buf.WriteString(c.placeHolder(at + c.limitAt))
+	}
+	if c.offsetAt > 0 {
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString("OFFSET ")
+		buf.WriteString(c.placeHolder(at + c.offsetAt))
+	}
+	return buf.String()
+}
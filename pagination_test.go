@@ -0,0 +1,81 @@
+package pqcomp_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/piotrkowalczuk/pqcomp"
+)
+
+func TestComposer_Paginate(t *testing.T) {
+	cases := map[string]struct {
+		max, limit, offset int64
+		expected           string
+		args               []interface{}
+	}{
+		"none":           {expected: "", args: []interface{}{"john"}},
+		"limit":          {limit: 10, expected: "LIMIT $2", args: []interface{}{"john", int64(10)}},
+		"offset":         {offset: 20, expected: "OFFSET $2", args: []interface{}{"john", int64(20)}},
+		"both":           {limit: 10, offset: 20, expected: "LIMIT $2 OFFSET $3", args: []interface{}{"john", int64(10), int64(20)}},
+		"negative":       {limit: -1, offset: -1, expected: "", args: []interface{}{"john"}},
+		"max-exceeded":   {max: 100, limit: 1000, expected: "LIMIT $2", args: []interface{}{"john", int64(100)}},
+		"max-absent":     {max: 100, offset: 5, expected: "LIMIT $2 OFFSET $3", args: []interface{}{"john", int64(100), int64(5)}},
+		"max-not-needed": {max: 100, limit: 50, expected: "LIMIT $2", args: []interface{}{"john", int64(50)}},
+	}
+
+	for hint, c := range cases {
+		comp := pqcomp.New(0, 0, 1, 0)
+		where := comp.Compose()
+		where.AddExpr("u.name", pqcomp.Equal, "john")
+		page := comp.Compose().SetMaxLimit(c.max)
+		page.Paginate(c.limit, c.offset)
+
+		if got := page.Pagination(); got != c.expected {
+			t.Errorf("%s: wrong pagination clause, expected %q but got %q", hint, c.expected, got)
+		}
+		if got := comp.Args(); !reflect.DeepEqual(c.args, got) {
+			t.Errorf("%s: wrong arguments, expected %v but got %v", hint, c.args, got)
+		}
+	}
+}
+
+func TestComposer_Paginate_order(t *testing.T) {
+	comp := pqcomp.NewWithFormat(pqcomp.Question, 0, 0, 1)
+	comp.Paginate(10, 20)
+	comp.AddExpr("u.name", pqcomp.Equal, "john")
+	comp.Compose().AddExpr("u.age", pqcomp.GreaterThan, 18)
+
+	expected := "WHERE u.name = ? AND (u.age > ?) LIMIT ? OFFSET ?"
+	if got := comp.Where() + " " + comp.Pagination(); got != expected {
+		t.Errorf("wrong query, expected %q but got %q", expected, got)
+	}
+	args := []interface{}{"john", 18, int64(10), int64(20)}
+	if got := comp.Args(); !reflect.DeepEqual(args, got) {
+		t.Errorf("wrong arguments, expected %v but got %v", args, got)
+	}
+
+	comp = pqcomp.New(0, 0)
+	comp.Paginate(10, 0)
+	comp.AddExpr("u.name", pqcomp.Equal, "john")
+
+	expected = "WHERE u.name = $1 LIMIT $2"
+	if got := comp.Where() + " " + comp.Pagination(); got != expected {
+		t.Errorf("wrong query, expected %q but got %q", expected, got)
+	}
+}
+
+func TestComposer_Paginate_twice(t *testing.T) {
+	comp := pqcomp.New(0, 0)
+	comp.AddExpr("u.name", pqcomp.Equal, "john")
+	comp.Paginate(10, 5)
+	comp.Paginate(20, 0)
+
+	expected := "WHERE u.name = $1 LIMIT $2"
+	if got := comp.Where() + " " + comp.Pagination(); got != expected {
+		t.Errorf("wrong query, expected %q but got %q", expected, got)
+	}
+	args := []interface{}{"john", int64(20)}
+	if got := comp.Args(); !reflect.DeepEqual(args, got) {
+		t.Errorf("wrong arguments, expected %v but got %v", args, got)
+	}
+}
@@ -2,8 +2,12 @@ package pqcomp
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -12,6 +16,12 @@ const (
 	Like = "LIKE"
 	// In represents IN operator.
 	In = "IN"
+	// NotIn represents NOT IN operator.
+	NotIn = "NOT IN"
+	// Between represents BETWEEN operator.
+	Between = "BETWEEN"
+	// NotBetween represents NOT BETWEEN operator.
+	NotBetween = "NOT BETWEEN"
 	// IsNull represents IS NULL keywords.
 	IsNull = "IS NULL"
 	// IsNotNull represents IS NOT NULL keywords.
@@ -38,6 +48,10 @@ const (
 	Any = "ANY"
 	// All ...
 	All = "ALL"
+	// EqualAny represents = ANY operator, slice value is bound as single array argument.
+	EqualAny = "= ANY"
+	// NotEqualAll represents <> ALL operator, slice value is bound as single array argument.
+	NotEqualAll = "<> ALL"
 	// Contains ...
 	Contains = "@>"
 	// IsContainedBy ...
@@ -50,13 +64,59 @@ const (
 	ExistsAny = "?|"
 	// ExistsAll ...
 	ExistsAll = "?&"
+	// And represents AND conjunction.
+	And = "AND"
+	// Or represents OR conjunction.
+	Or = "OR"
 )
 
 var (
 	// Empty ...
 	Empty = struct{}{}
+	// ErrNothingToUpdate is returned by Set if there are no expressions to render.
+	// UPDATE statement should be skipped in such case.
+	ErrNothingToUpdate = errors.New("pqcomp: nothing to update")
+	// ErrNoCursor is returned if cursor does not point to any expression,
+	// which happens before first call of Next or after Reset.
+	ErrNoCursor = errors.New("pqcomp: cursor does not point to any expression")
 )
 
+// PlaceHolderFormat formats placeholder for given position, that starts from 1.
+type PlaceHolderFormat func(n int) string
+
+var (
+	// Dollar formats numbered placeholders used by PostgreSQL: $1, $2, $3.
+	Dollar PlaceHolderFormat = func(n int) string {
+		return "$" + strconv.FormatInt(int64(n), 10)
+	}
+	// Question formats positional placeholders used by MySQL and SQLite: ?, ?, ?.
+	// Expressions have to be rendered in the same order as arguments are returned by Args.
+	Question PlaceHolderFormat = func(int) string {
+		return "?"
+	}
+	// AtP formats numbered placeholders used by SQL Server: @p1, @p2, @p3.
+	AtP PlaceHolderFormat = func(n int) string {
+		return "@p" + strconv.FormatInt(int64(n), 10)
+	}
+	// Colon formats numbered placeholders used by Oracle: :1, :2, :3.
+	Colon PlaceHolderFormat = func(n int) string {
+		return ":" + strconv.FormatInt(int64(n), 10)
+	}
+)
+
+// Expr represents single expression under the cursor.
+type Expr struct {
+	Key, Oper, PlaceHolder string
+}
+
+// String implements fmt.Stringer interface.
+func (e Expr) String() string {
+	if e.PlaceHolder == "" {
+		return e.Key + " " + e.Oper
+	}
+	return e.Key + " " + e.Oper + " " + e.PlaceHolder
+}
+
 // Appearer wraps Appear function.
 type Appearer interface {
 	// Appear returns true if object should be used by AddExpr method.
@@ -68,10 +128,27 @@ type Appearer interface {
 type Composer struct {
 	composed        int
 	keys, operators []string
+	arities         []int
+	offsets         []int
 	arguments       []interface{}
-	idx, diff       int
-	parent          *Composer
-	childs          []*Composer
+	idx             int
+	conjunction     string
+	negated         bool
+	format          PlaceHolderFormat
+	policy          Policy
+	strict          bool
+	validate        bool
+	errs            []error
+	order           *Order
+	maxLimit        int64
+	// assignment is true if composer collects SET clause, slices are bound as single value then.
+	assignment bool
+	// page holds pagination arguments, they follow arguments of descendants so they match the order of rendering.
+	page []interface{}
+	// limitAt and offsetAt are positions of pagination arguments in page counted from 1, zero if absent.
+	limitAt, offsetAt int
+	parent            *Composer
+	childs            []*Composer
 }
 
 // New allocates new Composer and pre-allocates space for given amount of arguments and expressions.
@@ -81,12 +158,31 @@ func New(args, nbOfExpressions int, nbOfExpressionsForEachChild ...int) *Compose
 	return neww(nil, args, nbOfExpressions, nbOfExpressionsForEachChild...)
 }
 
+// NewWithFormat works like New but placeholders are formatted using given format instead of Dollar.
+func NewWithFormat(format PlaceHolderFormat, args, nbOfExpressions int, nbOfExpressionsForEachChild ...int) *Composer {
+	comp := neww(nil, args, nbOfExpressions, nbOfExpressionsForEachChild...)
+	comp.format = format
+	return comp
+}
+
+// SetFormat sets format of placeholders. Placeholders are numbered across the whole tree,
+// so the format is stored in the root composer, even if called on a descendant.
+func (c *Composer) SetFormat(format PlaceHolderFormat) *Composer {
+	root := c
+	for root.parent != nil {
+		root = root.parent
+	}
+	root.format = format
+	return c
+}
+
 func neww(parent *Composer, args, pexpr int, cexpr ...int) *Composer {
 	comp := &Composer{
 		keys:      make([]string, 0, pexpr),
 		operators: make([]string, 0, pexpr),
+		arities:   make([]int, 0, pexpr),
+		offsets:   make([]int, 0, pexpr),
 		arguments: make([]interface{}, 0, args),
-		diff:      args,
 		childs:    make([]*Composer, len(cexpr)),
 		parent:    parent,
 	}
@@ -103,156 +199,457 @@ func (c *Composer) AddArg(arg interface{}) {
 }
 
 // AddExpr adds expression if value meet certain requirements.
+// Slice used with In or NotIn operator produces single expression with argument for each element,
+// with array operators like EqualAny it's bound as single argument (use pq.Array if driver requires it),
+// otherwise each element produces separate expression.
+// Nil pointers of any type are ignored. Non-nil pointers that implement Appearer or driver.Valuer are used as they are,
+// any other pointer is dereferenced and pointed value is treated the same way as if it was passed directly.
+// Null types from sql package (including generic sql.Null) are ignored if not valid,
+// any other driver.Valuer is ignored if its Value method returns nil.
+// Unary operators like IsNull do not bind the value, it only decides if expression is added,
+// so nil value drops the expression. To add it unconditionally use AddUnary or pass Empty.
+// Rules described above can be replaced by a policy, see SetPolicy.
 // To know more please read the source code.
 func (c *Composer) AddExpr(key, operator string, value interface{}) {
-	if value == nil {
+	c.AddExprWithPolicy(key, operator, value, c.currentPolicy())
+}
+
+// AddUnary adds expression with unary operator like IsNull unconditionally.
+// Operator that binds arguments is not added, instead an error is recorded and can be retrieved using Err.
+func (c *Composer) AddUnary(key, operator string) {
+	if op, _ := lookupOperator(operator); op.Arity != 0 {
+		c.errs = append(c.errs, fmt.Errorf("%w: operator %q is not unary", ErrArity, operator))
 		return
 	}
+	c.expr(key, operator, 0)
+}
 
-	switch v := value.(type) {
-	case struct{}:
+// AddExprWithPolicy works like AddExpr, but given policy decides if value should be used.
+func (c *Composer) AddExprWithPolicy(key, operator string, value interface{}, policy Policy) {
+	value = indirect(value)
+	if !policy(value) {
+		return
+	}
+	op, _ := lookupOperator(operator)
+	if op.Arity == 0 {
+		c.expr(key, operator, 0)
+		return
+	}
+	if c.assignment && op.Arity == 1 {
 		c.addExpr(key, operator, value)
+		return
+	}
+
+	switch v := value.(type) {
 	case []byte:
-		if v != nil {
-			c.addExpr(key, operator, value)
+		if op.Arity > 1 {
+			c.checkArity(op, 1)
+			return
 		}
-	case string:
-		c.addExpr(key, operator, value)
-	case int64:
-		c.addExpr(key, operator, value)
-	case int32:
-		c.addExpr(key, operator, value)
-	case int16:
-		c.addExpr(key, operator, value)
-	case int8:
-		c.addExpr(key, operator, value)
-	case int:
-		c.addExpr(key, operator, value)
-	case float32:
-		c.addExpr(key, operator, value)
-	case float64:
-		c.addExpr(key, operator, value)
-	case uint64:
-		c.addExpr(key, operator, value)
-	case uint32:
-		c.addExpr(key, operator, value)
-	case uint16:
-		c.addExpr(key, operator, value)
-	case uint8:
-		c.addExpr(key, operator, value)
-	case uint:
-		c.addExpr(key, operator, value)
-	case complex64:
-		c.addExpr(key, operator, value)
-	case complex128:
-		c.addExpr(key, operator, value)
-	case bool:
-		c.addExpr(key, operator, value)
+		c.addExpr(key, operator, v)
 	case []string:
-		for _, vv := range v {
-			c.addExpr(key, operator, vv)
-		}
+		addSlice(c, key, op, v)
 	case []int64:
-		for _, vv := range v {
-			c.addExpr(key, operator, vv)
-		}
+		addSlice(c, key, op, v)
 	case []int32:
-		for _, vv := range v {
-			c.addExpr(key, operator, vv)
-		}
+		addSlice(c, key, op, v)
 	case []int16:
-		for _, vv := range v {
-			c.addExpr(key, operator, vv)
-		}
+		addSlice(c, key, op, v)
 	case []int8:
-		for _, vv := range v {
-			c.addExpr(key, operator, vv)
-		}
+		addSlice(c, key, op, v)
 	case []int:
-		for _, vv := range v {
-			c.addExpr(key, operator, vv)
-		}
+		addSlice(c, key, op, v)
 	case []float32:
-		for _, vv := range v {
-			c.addExpr(key, operator, vv)
-		}
+		addSlice(c, key, op, v)
 	case []float64:
-		for _, vv := range v {
-			c.addExpr(key, operator, vv)
-		}
+		addSlice(c, key, op, v)
 	case []uint64:
-		for _, vv := range v {
-			c.addExpr(key, operator, vv)
-		}
+		addSlice(c, key, op, v)
 	case []uint32:
-		for _, vv := range v {
-			c.addExpr(key, operator, vv)
-		}
+		addSlice(c, key, op, v)
 	case []uint16:
-		for _, vv := range v {
-			c.addExpr(key, operator, vv)
-		}
+		addSlice(c, key, op, v)
 	case []uint:
-		for _, vv := range v {
-			c.addExpr(key, operator, vv)
-		}
+		addSlice(c, key, op, v)
 	case []complex64:
-		for _, vv := range v {
-			c.addExpr(key, operator, vv)
-		}
+		addSlice(c, key, op, v)
 	case []complex128:
-		for _, vv := range v {
-			c.addExpr(key, operator, vv)
-		}
+		addSlice(c, key, op, v)
 	case []bool:
-		for _, vv := range v {
-			c.addExpr(key, operator, vv)
+		addSlice(c, key, op, v)
+	default:
+		if op.Arity == List || op.Arity > 1 {
+			if vo := reflect.ValueOf(v); vo.Kind() == reflect.Slice {
+				if vo.Len() == 0 {
+					return
+				}
+				if !c.checkArity(op, vo.Len()) || !c.expr(key, operator, vo.Len()) {
+					return
+				}
+				for i := 0; i < vo.Len(); i++ {
+					c.arguments = append(c.arguments, vo.Index(i).Interface())
+				}
+				return
+			}
+		}
+		if op.Arity > 1 {
+			c.checkArity(op, 1)
+			return
+		}
+		c.addExpr(key, operator, v)
+	}
+}
+
+// Add adds expression if given function returns true for the value or if function is nil.
+// Unlike AddExpr it does not inspect the value using type switch or reflection,
+// so the value is bound as single argument even if it's a slice.
+// List operators like In need each element bound separately, so they are not added, instead an error is recorded.
+// Add does not avoid boxing: arguments are returned by Args as []interface{},
+// so value that is not a pointer allocates once it's stored, the same as if it was passed to AddExpr.
+func Add[T any](c *Composer, key, operator string, value T, appear func(T) bool) {
+	if appear != nil && !appear(value) {
+		return
+	}
+	switch op, _ := lookupOperator(operator); {
+	case op.Arity == 0:
+		c.expr(key, operator, 0)
+		return
+	case op.Arity == List:
+		c.errs = append(c.errs, fmt.Errorf("%w: operator %q expects list, use AddExpr", ErrArity, operator))
+		return
+	case op.Arity > 1:
+		c.checkArity(op, 1)
+		return
+	}
+	c.addExpr(key, operator, value)
+}
+
+// AddPtr works like Add, except that nil pointer is ignored and non-nil one is dereferenced.
+func AddPtr[T any](c *Composer, key, operator string, value *T, appear func(T) bool) {
+	if value == nil {
+		return
+	}
+	Add(c, key, operator, *value, appear)
+}
+
+// NotZero returns true if value is not equal to zero value of its type.
+// It can be used as an argument of Add.
+func NotZero[T comparable](value T) bool {
+	var zero T
+	return value != zero
+}
+
+// Valid returns true if sql.Null value is valid.
+// It can be used as an argument of Add.
+func Valid[T any](value sql.Null[T]) bool {
+	return value.Valid
+}
+
+// AddBetween adds BETWEEN expression if both bounds meet the same requirements as AddExpr value.
+// If only one of them does, expression degrades to greater than or equal (from)
+// or lower than or equal (to) expression.
+func (c *Composer) AddBetween(key string, from, to interface{}) {
+	c.addBetween(key, Between, GreaterThanOrEqual, LessThanOrEqual, from, to)
+}
+
+// AddNotBetween adds NOT BETWEEN expression if both bounds meet the same requirements as AddExpr value.
+// If only one of them does, expression degrades to lower than (from)
+// or greater than (to) expression.
+func (c *Composer) AddNotBetween(key string, from, to interface{}) {
+	c.addBetween(key, NotBetween, LessThan, GreaterThan, from, to)
+}
+
+func (c *Composer) addBetween(key, operator, fromOperator, toOperator string, from, to interface{}) {
+	from, to = indirect(from), indirect(to)
+	fok, tok := c.currentPolicy()(from), c.currentPolicy()(to)
+	switch {
+	case fok && tok:
+		if !c.expr(key, operator, 2) {
+			return
+		}
+		c.arguments = append(c.arguments, from, to)
+	case fok:
+		c.addExpr(key, fromOperator, from)
+	case tok:
+		c.addExpr(key, toOperator, to)
+	}
+}
+
+// AddRange adds half-open range, that is greater than or equal (from) and lower than (to) expressions.
+// Each bound is added only if it meets the same requirements as AddExpr value.
+func (c *Composer) AddRange(key string, from, to interface{}) {
+	from, to = indirect(from), indirect(to)
+	policy := c.currentPolicy()
+	if policy(from) {
+		c.addExpr(key, GreaterThanOrEqual, from)
+	}
+	if policy(to) {
+		c.addExpr(key, LessThan, to)
+	}
+}
+
+// indirect dereferences pointer, unless it implements Appearer or driver.Valuer.
+// Nil pointer of any type is returned as untyped nil.
+func indirect(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil, struct{}, string, bool,
+		int64, int32, int16, int8, int,
+		uint64, uint32, uint16, uint8, uint,
+		float32, float64, complex64, complex128,
+		time.Time, []byte:
+		return value
+	case *string:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case *int64:
+		if v == nil {
+			return nil
 		}
+		return *v
+	case *int:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case *float64:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case *bool:
+		if v == nil {
+			return nil
+		}
+		return *v
 	case *time.Time:
-		if v != nil && !v.IsZero() {
-			c.addExpr(key, operator, value)
+		if v == nil {
+			return nil
 		}
-	case time.Time:
-		if !v.IsZero() {
-			c.addExpr(key, operator, value)
+		return *v
+	case Appearer, driver.Valuer:
+		if vo := reflect.ValueOf(v); vo.Kind() == reflect.Ptr && vo.IsNil() {
+			return nil
 		}
-	case Appearer:
-		if v.Appear() {
-			c.addExpr(key, operator, value)
+		return value
+	default:
+		vo := reflect.ValueOf(v)
+		if vo.Kind() != reflect.Ptr {
+			return value
 		}
-	case *sql.NullBool:
-		if v != nil && v.Valid {
-			c.addExpr(key, operator, value)
+		if vo.IsNil() {
+			return nil
 		}
-	case *sql.NullString:
-		if v != nil && v.Valid {
-			c.addExpr(key, operator, value)
+		return indirect(vo.Elem().Interface())
+	}
+}
+
+// Policy decides if value should be used by AddExpr. Pointers are dereferenced before policy is applied,
+// and nil pointer is passed as untyped nil.
+type Policy func(value interface{}) bool
+
+var (
+	// DefaultPolicy is used by AddExpr unless other policy is set. Rules are described in AddExpr documentation.
+	DefaultPolicy Policy = appear
+	// NilOnly policy ignores only nil values, nil slices and maps, Appearer that does not appear and
+	// driver.Valuer (including sql Null types) that returns nil. Unlike DefaultPolicy it allows zero time.Time.
+	NilOnly Policy = nilOnly
+	// ZeroIsAbsent policy works like DefaultPolicy, but additionally ignores zero values of any type,
+	// like empty string, 0, false or empty slice. Empty is always used.
+	ZeroIsAbsent Policy = zeroIsAbsent
+)
+
+// SetPolicy sets policy that is used by AddExpr and similar methods.
+// Child composers that have no policy set inherit it from the parent.
+func (c *Composer) SetPolicy(policy Policy) *Composer {
+	c.policy = policy
+	return c
+}
+
+func (c *Composer) currentPolicy() Policy {
+	for cc := c; cc != nil; cc = cc.parent {
+		if cc.policy != nil {
+			return cc.policy
 		}
+	}
+	return DefaultPolicy
+}
+
+func nilOnly(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case Appearer:
+		return v.Appear()
+	case driver.Valuer:
+		val, err := v.Value()
+		return err != nil || val != nil
+	}
+
+	switch vo := reflect.ValueOf(value); vo.Kind() {
+	case reflect.Slice, reflect.Map:
+		return !vo.IsNil()
+	default:
+		return true
+	}
+}
+
+func zeroIsAbsent(value interface{}) bool {
+	if !appear(value) {
+		return false
+	}
+
+	switch v := value.(type) {
+	case struct{}:
+		return true
+	case string:
+		return v != ""
+	case bool:
+		return v
+	case int64:
+		return v != 0
+	case int:
+		return v != 0
+	case Appearer, driver.Valuer:
+		return true
+	}
+
+	switch vo := reflect.ValueOf(value); vo.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return vo.Len() != 0
+	default:
+		return !vo.IsZero()
+	}
+}
+
+// appear returns true if value meet requirements of AddExpr.
+func appear(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+
+	switch v := value.(type) {
+	case struct{}, string, bool,
+		int64, int32, int16, int8, int,
+		uint64, uint32, uint16, uint8, uint,
+		float32, float64, complex64, complex128:
+		return true
+	case []byte:
+		return v != nil
+	case time.Time:
+		return !v.IsZero()
+	case Appearer:
+		return v.Appear()
+	case *sql.NullBool:
+		return v.Valid
+	case *sql.NullString:
+		return v.Valid
 	case *sql.NullInt64:
-		if v != nil && v.Valid {
-			c.addExpr(key, operator, value)
-		}
+		return v.Valid
+	case *sql.NullInt32:
+		return v.Valid
+	case *sql.NullInt16:
+		return v.Valid
+	case *sql.NullByte:
+		return v.Valid
 	case *sql.NullFloat64:
-		if v != nil && v.Valid {
-			c.addExpr(key, operator, value)
-		}
+		return v.Valid
+	case *sql.NullTime:
+		return v.Valid
+	case sql.NullBool:
+		return v.Valid
+	case sql.NullString:
+		return v.Valid
+	case sql.NullInt64:
+		return v.Valid
+	case sql.NullInt32:
+		return v.Valid
+	case sql.NullInt16:
+		return v.Valid
+	case sql.NullByte:
+		return v.Valid
+	case sql.NullFloat64:
+		return v.Valid
+	case sql.NullTime:
+		return v.Valid
+	case driver.Valuer:
+		// Valuer that fails is kept, so the error can be reported by the driver.
+		val, err := v.Value()
+		return err != nil || val != nil
 	default:
 		vo := reflect.ValueOf(v)
-		switch vo.Kind() {
-		case reflect.Slice:
-			if !vo.IsNil() {
-				c.addExpr(key, operator, value)
-			}
-		default:
-			c.addExpr(key, operator, value)
+		if vo.Kind() == reflect.Slice {
+			return !vo.IsNil()
 		}
+		return true
 	}
 }
 
 func (c *Composer) addExpr(key, expr string, value interface{}) {
+	if !c.expr(key, expr, 1) {
+		return
+	}
+	c.arguments = append(c.arguments, value)
+}
+
+// expr registers expression that binds given number of arguments.
+// Arguments have to be appended right after, unless it returns false.
+// In strict mode key is validated and quoted, invalid key is recorded as an error and expression is not registered.
+// The same happens to unknown operator if operator validation is enabled.
+func (c *Composer) expr(key, expr string, arity int) bool {
+	if c.validatesOperators() {
+		if _, ok := lookupOperator(expr); !ok {
+			c.errs = append(c.errs, fmt.Errorf("%w: %q", ErrUnknownOperator, expr))
+			return false
+		}
+	}
+	if c.isStrict() {
+		quoted, err := QuoteIdent(key)
+		if err != nil {
+			c.errs = append(c.errs, err)
+			return false
+		}
+		key = quoted
+	}
+
+	c.register(key, expr, arity)
+	return true
+}
+
+// register registers expression without any validation.
+func (c *Composer) register(key, expr string, arity int) {
 	c.keys = append(c.keys, key)
 	c.operators = append(c.operators, expr)
-	c.arguments = append(c.arguments, value)
+	c.arities = append(c.arities, arity)
+	c.offsets = append(c.offsets, len(c.arguments))
+}
+
+// addSlice adds slice as a list of arguments if operator is In, NotIn or other operator of fixed arity greater than one,
+// as a single array argument if it's an array operator like EqualAny
+// or otherwise as separate expression for each element.
+func addSlice[T any](c *Composer, key string, op Operator, v []T) {
+	operator := op.Name
+	switch {
+	case op.Arity == List || op.Arity > 1:
+		if len(v) == 0 {
+			return
+		}
+		if !c.checkArity(op, len(v)) || !c.expr(key, operator, len(v)) {
+			return
+		}
+		for _, vv := range v {
+			c.arguments = append(c.arguments, vv)
+		}
+	case op.Array:
+		if v != nil {
+			c.addExpr(key, operator, v)
+		}
+	default:
+		for _, vv := range v {
+			c.addExpr(key, operator, vv)
+		}
+	}
 }
 
 // Compose returns next available composer
@@ -262,9 +659,9 @@ func (c *Composer) Compose(nbOfChildExpressions ...int) (comp *Composer) {
 		comp = c.childs[c.composed]
 
 		if len(nbOfChildExpressions) != 0 {
-			comp.childs = make([]*Composer, 0, len(nbOfChildExpressions))
+			comp.childs = make([]*Composer, len(nbOfChildExpressions))
 			for i := range comp.childs {
-				comp.childs[i] = neww(c, nbOfChildExpressions[i], nbOfChildExpressions[i])
+				comp.childs[i] = neww(comp, nbOfChildExpressions[i], nbOfChildExpressions[i])
 			}
 		}
 		c.composed++
@@ -278,24 +675,30 @@ func (c *Composer) Compose(nbOfChildExpressions ...int) (comp *Composer) {
 }
 
 // Args returns slice of arguments that was passed to the composer
-// or to any child.
+// or to any descendant. Composer arguments come first,
+// followed by arguments of each child in order of composition, recursively.
+// Arguments added by Paginate come after arguments of descendants.
 func (c *Composer) Args() []interface{} {
-	if len(c.childs) == 0 {
+	if len(c.childs) == 0 && len(c.page) == 0 {
 		return c.arguments
 	}
 
-	args := make([]interface{}, 0, c.lenWithChilds())
-	args = append(args, c.arguments...)
+	return c.args(make([]interface{}, 0, c.lenWithChilds()))
+}
+
+func (c *Composer) args(dst []interface{}) []interface{} {
+	dst = append(dst, c.arguments...)
 	for _, ch := range c.childs {
-		args = append(args, ch.arguments...)
+		dst = ch.args(dst)
 	}
 
-	return args
+	return append(dst, c.page...)
 }
 
 func (c *Composer) lenWithChilds() (count int) {
+	count = len(c.arguments) + len(c.page)
 	for _, ch := range c.childs {
-		count += len(ch.arguments)
+		count += ch.lenWithChilds()
 	}
 
 	return
@@ -310,9 +713,6 @@ func (b *Composer) Len() int {
 func (b *Composer) Next() bool {
 	if b.idx < b.Len() {
 		b.idx++
-		if b.parent != nil {
-			b.parent.idx++
-		}
 		return true
 	}
 
@@ -324,25 +724,238 @@ func (b *Composer) Reset() {
 	b.idx = 0
 }
 
+// Expr returns expression for current cursor position.
+// If cursor does not point to any expression ErrNoCursor is returned.
+func (b *Composer) Expr() (Expr, error) {
+	if b.idx == 0 {
+		return Expr{}, ErrNoCursor
+	}
+	return Expr{
+		Key:         b.keys[b.idx-1],
+		Oper:        b.operators[b.idx-1],
+		PlaceHolder: b.PlaceHolder(),
+	}, nil
+}
+
 // Key returns key for current cursor position.
+// If cursor does not point to any expression, empty string is returned.
 func (b *Composer) Key() string {
+	if b.idx == 0 {
+		return ""
+	}
 	return b.keys[b.idx-1]
 }
 
 // Oper returns operator for current cursor position.
+// If cursor does not point to any expression, empty string is returned.
 func (b *Composer) Oper() string {
+	if b.idx == 0 {
+		return ""
+	}
 	return b.operators[b.idx-1]
 }
 
 // PlaceHolder returns placeholder for current cursor position.
+// Placeholders are numbered after position of the argument in slice returned by Args method of the root composer,
+// so result does not depend on the order of iteration.
+// Expressions with In and NotIn operator produce parenthesized list of placeholders, one for each element: ($1, $2, $3).
+// Array operators like EqualAny produce single parenthesized placeholder: ($1).
+// Between and NotBetween produce pair of placeholders: $1 AND $2.
+// Custom operators are rendered according to their registration, see RegisterOperator.
+// Unary operators like IsNull produce empty string.
+// If cursor does not point to any expression, empty string is returned.
 func (b *Composer) PlaceHolder() string {
+	if b.idx == 0 {
+		return ""
+	}
+
+	op, _ := lookupOperator(b.operators[b.idx-1])
+	arity := b.arities[b.idx-1]
+	first := b.offset() + b.offsets[b.idx-1] + 1
+	switch {
+	case arity == 0:
+		return ""
+	case op.Render == nil && arity == 1:
+		return b.placeHolder(first)
+	}
+
+	placeholders := make([]string, arity)
+	for i := range placeholders {
+		placeholders[i] = b.placeHolder(first + i)
+	}
+	if op.Render == nil {
+		return renderList(placeholders)
+	}
+	return op.Render(placeholders)
+}
+
+// offset returns position of the first composer argument in slice returned by Args method of the root composer.
+func (b *Composer) offset() int {
+	if b.parent == nil {
+		return 0
+	}
+
+	offset := b.parent.offset() + len(b.parent.arguments)
+	for _, ch := range b.parent.childs {
+		if ch == b {
+			break
+		}
+		offset += ch.lenWithChilds()
+	}
+	return offset
+}
+
+// placeHolder formats placeholder for given position using format of the root composer.
+func (b *Composer) placeHolder(n int) string {
 	if b.parent != nil {
-		return b.parent.PlaceHolder()
+		return b.parent.placeHolder(n)
 	}
-	return "$" + strconv.FormatInt(int64(b.diff+b.idx), 10)
+	if b.format != nil {
+		return b.format(n)
+	}
+	return Dollar(n)
 }
 
 // First returns true cursor is on first position.
 func (b *Composer) First() bool {
 	return b.idx == 1
 }
+
+// Where renders expressions as WHERE clause.
+// Expressions are joined using conjunction of the composer (AND by default),
+// each non empty child composer is rendered as a group in parentheses.
+// Cursor is reset before and after rendering, so iteration still can be used afterwards.
+// It returns empty string if there are no expressions.
+func (b *Composer) Where() string {
+	return b.clause("WHERE")
+}
+
+// Having works like Where, but renders HAVING clause.
+func (b *Composer) Having() string {
+	return b.clause("HAVING")
+}
+
+func (b *Composer) clause(keyword string) string {
+	if b.empty() {
+		return ""
+	}
+
+	var buf strings.Builder
+	buf.WriteString(keyword)
+	buf.WriteString(" ")
+	if b.negated {
+		buf.WriteString("NOT (")
+		b.condition(&buf)
+		buf.WriteString(")")
+	} else {
+		b.condition(&buf)
+	}
+
+	return buf.String()
+}
+
+// Join sets conjunction (And or Or) that is used to join expressions and groups of the composer.
+// Any other conjunction is not set, instead an error is recorded and can be retrieved using Err.
+func (b *Composer) Join(conjunction string) *Composer {
+	if conjunction != And && conjunction != Or {
+		b.errs = append(b.errs, fmt.Errorf("pqcomp: invalid conjunction %q", conjunction))
+		return b
+	}
+	b.conjunction = conjunction
+	return b
+}
+
+// Not negates whole group of expressions represented by the composer.
+func (b *Composer) Not() *Composer {
+	b.negated = !b.negated
+	return b
+}
+
+func (b *Composer) condition(buf *strings.Builder) {
+	conj := " " + And + " "
+	if b.conjunction != "" {
+		conj = " " + b.conjunction + " "
+	}
+
+	first := true
+	b.Reset()
+	for b.Next() {
+		if !first {
+			buf.WriteString(conj)
+		}
+		first = false
+		buf.WriteString(b.Key())
+		buf.WriteString(" ")
+		buf.WriteString(b.Oper())
+		if ph := b.PlaceHolder(); ph != "" {
+			buf.WriteString(" ")
+			buf.WriteString(ph)
+		}
+	}
+
+	for _, ch := range b.childs {
+		if ch.empty() {
+			continue
+		}
+		if !first {
+			buf.WriteString(conj)
+		}
+		first = false
+		if ch.negated {
+			buf.WriteString("NOT ")
+		}
+		buf.WriteString("(")
+		ch.condition(buf)
+		buf.WriteString(")")
+	}
+	b.Reset()
+}
+
+// empty returns true if neither composer nor any of its children has an expression.
+func (b *Composer) empty() bool {
+	if b.Len() != 0 {
+		return false
+	}
+	for _, ch := range b.childs {
+		if !ch.empty() {
+			return false
+		}
+	}
+	return true
+}
+
+// Set renders expressions as SET clause of UPDATE statement.
+// Only Equal operator is allowed and each key can be assigned once, otherwise error is returned.
+// Note that slice passed to AddExpr with Equal operator produces expression for each element.
+// If there are no expressions ErrNothingToUpdate is returned.
+func (b *Composer) Set() (string, error) {
+	if b.Len() == 0 {
+		return "", ErrNothingToUpdate
+	}
+	for i, op := range b.operators {
+		if op != Equal {
+			return "", fmt.Errorf("pqcomp: operator %q is not allowed in SET clause", op)
+		}
+		for _, key := range b.keys[:i] {
+			if key == b.keys[i] {
+				return "", fmt.Errorf("pqcomp: multiple assignments to %q in SET clause", key)
+			}
+		}
+	}
+
+	var buf strings.Builder
+	buf.WriteString("SET ")
+
+	b.Reset()
+	for b.Next() {
+		if !b.First() {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(b.Key())
+		buf.WriteString(" = ")
+		buf.WriteString(b.PlaceHolder())
+	}
+	b.Reset()
+
+	return buf.String(), nil
+}
@@ -132,12 +132,27 @@ func BenchmarkComposer_AddExpr(b *testing.B) {
 	}
 }
 
-func BenchmarkComposer_Placeholder(b *testing.B) {
+func BenchmarkAdd(b *testing.B) {
+	column := "column"
+	arg := "argument"
 	comp := pqcomp.New(0, b.N)
 
 	b.ResetTimer()
 	b.ReportAllocs()
 
+	for n := 0; n < b.N; n++ {
+		pqcomp.Add(comp, column, pqcomp.Equal, arg, pqcomp.NotZero[string])
+	}
+}
+
+func BenchmarkComposer_Placeholder(b *testing.B) {
+	comp := pqcomp.New(0, 1)
+	comp.AddExpr("column", pqcomp.Equal, "argument")
+	comp.Next()
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
 	for n := 0; n < b.N; n++ {
 		benchmarkPlaceholder = comp.PlaceHolder()
 	}
@@ -175,6 +190,19 @@ func BenchmarkComposer_Args(b *testing.B) {
 	}
 }
 
+func BenchmarkComposer_Compose(b *testing.B) {
+	b.ReportAllocs()
+
+	for n := 0; n < b.N; n++ {
+		comp = pqcomp.New(0, 0, 2)
+		where = comp.Compose(10, 10)
+		where.Compose().AddExpr("column", pqcomp.Equal, "value")
+		where.Compose().AddExpr("column", pqcomp.Equal, "value")
+		update = comp.Compose()
+		update.AddExpr("column", pqcomp.Equal, "value")
+	}
+}
+
 func BenchmarkComposer_New(b *testing.B) {
 	b.ReportAllocs()
 
@@ -182,3 +210,41 @@ func BenchmarkComposer_New(b *testing.B) {
 		comp = pqcomp.New(100, 100)
 	}
 }
+
+func BenchmarkComposer_AddStruct(b *testing.B) {
+	type filter struct {
+		Name  *string `pqcomp:"u.name"`
+		Age   *int64  `pqcomp:"u.age,>="`
+		Email string  `pqcomp:"u.email,LIKE"`
+	}
+	name, age := "john", int64(18)
+	f := &filter{Name: &name, Age: &age, Email: "%@example.com"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		comp = pqcomp.New(0, 3)
+		if err := comp.AddStruct(f); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkInsert_ToSQL(b *testing.B) {
+	ins := pqcomp.NewInsert("users", "username", "age")
+	for i := 0; i < 1000; i++ {
+		if err := ins.Values("johnsnow", i); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		if _, _, err := ins.ToSQL(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
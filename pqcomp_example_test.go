@@ -9,9 +9,6 @@ import (
 )
 
 func Example() {
-	var (
-		uquery, wquery string
-	)
 	comp := pqcomp.New(1, 1, 1, 3)
 	update := comp.Compose()
 	where := comp.Compose()
@@ -29,22 +26,11 @@ func Example() {
 		return
 	}
 
-	for update.Next() {
-		if update.First() {
-			uquery += "SET "
-		} else {
-			uquery += ", "
-		}
-		uquery += fmt.Sprintf("%s %s %s", update.Key(), update.Oper(), update.PlaceHolder())
-	}
-	for where.Next() {
-		if where.First() {
-			wquery += "WHERE "
-		} else {
-			wquery += ", "
-		}
-		wquery += fmt.Sprintf("%s %s %s", where.Key(), where.Oper(), where.PlaceHolder())
+	uquery, err := update.Set()
+	if err != nil {
+		return
 	}
+	wquery := where.Where()
 
 	fmt.Println(where.Args()...)
 	fmt.Println(update.Args()...)
@@ -57,3 +43,22 @@ func Example() {
 	// 10 johnsnow John &{Snow true} 1
 	// UPDATE users AS u SET u.username = $2, u.first_name = $3, u.last_name = $4 WHERE u.id = $5 LIMIT $1
 }
+
+func ExampleNewSelect() {
+	sel := pqcomp.NewSelect("u.id", "u.username").From("users AS u")
+	sel.Where().AddExpr("u.first_name", pqcomp.Equal, "John")
+	sel.Where().AddExpr("u.age", pqcomp.GreaterThan, &sql.NullInt64{Int64: 1000, Valid: false})
+	sel.Paginate(10, 0)
+
+	query, args, err := sel.ToSQL()
+	if err != nil {
+		return
+	}
+
+	fmt.Println(query)
+	fmt.Println(args...)
+
+	// Output:
+	// SELECT u.id, u.username FROM users AS u WHERE u.first_name = $1 LIMIT $2
+	// John 10
+}
@@ -2,6 +2,8 @@ package pqcomp_test
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -17,6 +19,35 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestComposer_Compose(t *testing.T) {
+	comp := pqcomp.New(0, 0, 1)
+	child := comp.Compose(2, 3)
+	grandchildA := child.Compose()
+	grandchildB := child.Compose()
+
+	grandchildA.AddExpr("a", pqcomp.Equal, 1)
+	grandchildA.AddExpr("b", pqcomp.Equal, 2)
+	grandchildB.AddExpr("c", pqcomp.Equal, 3)
+
+	if cap(comp.Compose().Args()) != 0 {
+		t.Errorf("new composer should not pre-allocate arguments")
+	}
+	if cap(grandchildA.Args()) != 2 {
+		t.Errorf("wrong grandchild A capacity, expected %d but got %d", 2, cap(grandchildA.Args()))
+	}
+	if cap(grandchildB.Args()) != 3 {
+		t.Errorf("wrong grandchild B capacity, expected %d but got %d", 3, cap(grandchildB.Args()))
+	}
+	if len(comp.Args()) != 3 {
+		t.Errorf("wrong number of arguments, expected %d but got %d", 3, len(comp.Args()))
+	}
+
+	expected := "WHERE (a = $1 AND b = $2) AND (c = $3)"
+	if got := child.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+}
+
 func TestComposer_AddArg(t *testing.T) {
 	max := 100
 
@@ -40,6 +71,45 @@ func TestComposer_AddArg(t *testing.T) {
 	}
 }
 
+func TestComposer_Args_nested(t *testing.T) {
+	cases := map[string]struct {
+		depth, width int
+	}{
+		"depth-1-width-1": {depth: 1, width: 1},
+		"depth-2-width-2": {depth: 2, width: 2},
+		"depth-3-width-1": {depth: 3, width: 1},
+		"depth-3-width-3": {depth: 3, width: 3},
+		"depth-5-width-2": {depth: 5, width: 2},
+	}
+
+	for hint, c := range cases {
+		var (
+			expected []interface{}
+			compose  func(*pqcomp.Composer, int)
+		)
+		compose = func(comp *pqcomp.Composer, depth int) {
+			comp.AddArg(len(expected))
+			expected = append(expected, len(expected))
+			comp.AddExpr("column", pqcomp.Equal, len(expected))
+			expected = append(expected, len(expected))
+
+			if depth == 0 {
+				return
+			}
+			for i := 0; i < c.width; i++ {
+				compose(comp.Compose(), depth-1)
+			}
+		}
+
+		comp := pqcomp.New(0, 0)
+		compose(comp, c.depth)
+
+		if got := comp.Args(); !reflect.DeepEqual(expected, got) {
+			t.Errorf("%s: wrong arguments, expected %v but got %v", hint, expected, got)
+		}
+	}
+}
+
 func TestComposer_AddExpr(t *testing.T) {
 	max := 99
 	expected := max / 3
@@ -124,6 +194,85 @@ func TestComposer_AddArg_slices(t *testing.T) {
 	}
 }
 
+func TestComposer_AddExpr_in(t *testing.T) {
+	type id int64
+
+	comp := pqcomp.New(0, 0)
+	comp.AddExpr("a", pqcomp.Equal, 1)
+	comp.AddExpr("b", pqcomp.In, []int64{1, 2, 3})
+	comp.AddExpr("c", pqcomp.NotIn, []string{"x", "y"})
+	comp.AddExpr("d", pqcomp.In, []int64{})
+	comp.AddExpr("e", pqcomp.In, []id{4, 5})
+	comp.AddExpr("f", pqcomp.In, 6)
+
+	expected := "WHERE a = $1 AND b IN ($2, $3, $4) AND c NOT IN ($5, $6) AND e IN ($7, $8) AND f IN ($9)"
+	if got := comp.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+	if comp.Len() != 5 {
+		t.Errorf("wrong number of expressions, expected %d but got %d", 5, comp.Len())
+	}
+	if len(comp.Args()) != 9 {
+		t.Errorf("wrong number of arguments, expected %d but got %d", 9, len(comp.Args()))
+	}
+}
+
+func TestComposer_AddExpr_any(t *testing.T) {
+	ids := []int64{1, 2, 3}
+
+	comp := pqcomp.New(0, 0)
+	comp.AddExpr("a", pqcomp.EqualAny, ids)
+	comp.AddExpr("b", pqcomp.NotEqualAll, []string{"x"})
+	comp.AddExpr("c", pqcomp.EqualAny, []string(nil))
+
+	expected := "WHERE a = ANY ($1) AND b <> ALL ($2)"
+	if got := comp.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+	if args := comp.Args(); len(args) != 2 || !reflect.DeepEqual(args[0], ids) {
+		t.Errorf("slice should be bound as single argument, got %v", args)
+	}
+}
+
+func TestComposer_AddBetween(t *testing.T) {
+	var nilTime *time.Time
+	from := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	comp := pqcomp.New(0, 0)
+	comp.AddExpr("a", pqcomp.Equal, 1)
+	comp.AddBetween("b", &from, &to)
+	comp.AddBetween("c", &from, nilTime)
+	comp.AddBetween("d", nilTime, &to)
+	comp.AddBetween("e", nilTime, &time.Time{})
+	comp.AddNotBetween("f", 1, 2)
+	comp.AddNotBetween("g", &sql.NullInt64{}, 2)
+
+	expected := "WHERE a = $1 AND b BETWEEN $2 AND $3 AND c >= $4 AND d <= $5 AND f NOT BETWEEN $6 AND $7 AND g > $8"
+	if got := comp.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+	if len(comp.Args()) != 8 {
+		t.Errorf("wrong number of arguments, expected %d but got %d", 8, len(comp.Args()))
+	}
+}
+
+func TestComposer_AddRange(t *testing.T) {
+	var nilTime *time.Time
+	from := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	comp := pqcomp.New(0, 0)
+	comp.AddRange("a", &from, &to)
+	comp.AddRange("b", nilTime, &to)
+	comp.AddRange("c", &from, nilTime)
+
+	expected := "WHERE a >= $1 AND a < $2 AND b < $3 AND c >= $4"
+	if got := comp.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+}
+
 func TestComposer_AddExpr_sql(t *testing.T) {
 	comp := pqcomp.New(0, 0)
 	comp.AddExpr("int64-valid", pqcomp.Equal, &sql.NullInt64{Int64: 1, Valid: true})
@@ -140,6 +289,55 @@ func TestComposer_AddExpr_sql(t *testing.T) {
 	}
 }
 
+func TestComposer_AddExpr_sqlAll(t *testing.T) {
+	now := time.Now()
+
+	valid := []interface{}{
+		sql.NullTime{Time: now, Valid: true},
+		&sql.NullTime{Time: now, Valid: true},
+		sql.NullInt32{Int32: 1, Valid: true},
+		&sql.NullInt32{Int32: 1, Valid: true},
+		sql.NullInt16{Int16: 1, Valid: true},
+		&sql.NullInt16{Int16: 1, Valid: true},
+		sql.NullByte{Byte: 1, Valid: true},
+		&sql.NullByte{Byte: 1, Valid: true},
+		sql.Null[int64]{V: 1, Valid: true},
+		&sql.Null[string]{V: "1", Valid: true},
+		valuer("value"),
+	}
+	invalid := []interface{}{
+		sql.NullBool{},
+		sql.NullString{},
+		sql.NullInt64{},
+		sql.NullFloat64{},
+		sql.NullTime{},
+		&sql.NullTime{},
+		sql.NullInt32{},
+		&sql.NullInt32{},
+		sql.NullInt16{},
+		&sql.NullInt16{},
+		sql.NullByte{},
+		&sql.NullByte{},
+		sql.Null[int64]{},
+		&sql.Null[string]{},
+		(*sql.Null[string])(nil),
+		valuer(""),
+		(*sql.NullTime)(nil),
+	}
+
+	comp := pqcomp.New(0, len(valid))
+	for _, v := range valid {
+		comp.AddExpr("valid", pqcomp.Equal, v)
+	}
+	for _, v := range invalid {
+		comp.AddExpr("invalid", pqcomp.Equal, v)
+	}
+
+	if comp.Len() != len(valid) {
+		t.Errorf("wrong number of expressions, expected %d but got %d", len(valid), comp.Len())
+	}
+}
+
 func TestComposer_AddExpr_time(t *testing.T) {
 	var tt *time.Time
 	now := time.Now()
@@ -170,6 +368,129 @@ func TestComposer_AddExpr_nil(t *testing.T) {
 	}
 }
 
+func TestAdd(t *testing.T) {
+	var nilInt64 *int64
+	age := int64(30)
+
+	comp := pqcomp.New(0, 0)
+	pqcomp.Add(comp, "name", pqcomp.Equal, "john", nil)
+	pqcomp.Add(comp, "empty", pqcomp.Equal, "", pqcomp.NotZero[string])
+	pqcomp.Add(comp, "limit", pqcomp.LessThan, 10, pqcomp.NotZero[int])
+	pqcomp.Add(comp, "valid", pqcomp.Equal, sql.Null[string]{V: "x", Valid: true}, pqcomp.Valid[string])
+	pqcomp.Add(comp, "invalid", pqcomp.Equal, sql.Null[string]{}, pqcomp.Valid[string])
+	pqcomp.AddPtr(comp, "age", pqcomp.GreaterThan, &age, nil)
+	pqcomp.AddPtr(comp, "nil", pqcomp.GreaterThan, nilInt64, nil)
+	pqcomp.AddPtr(comp, "zero", pqcomp.GreaterThan, new(int64), pqcomp.NotZero[int64])
+
+	expected := "WHERE name = $1 AND limit < $2 AND valid = $3 AND age > $4"
+	if got := comp.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+	if got := comp.Args(); got[3] != age {
+		t.Errorf("pointer should be dereferenced, got %v", got[3])
+	}
+}
+
+func TestAdd_list(t *testing.T) {
+	comp := pqcomp.New(0, 0)
+	pqcomp.Add(comp, "id", pqcomp.In, []int64{1, 2}, nil)
+
+	if got := comp.Where(); got != "" {
+		t.Errorf("wrong where clause, expected empty but got %q", got)
+	}
+	if err := comp.Err(); !errors.Is(err, pqcomp.ErrArity) {
+		t.Errorf("wrong error, expected %v but got %v", pqcomp.ErrArity, err)
+	}
+}
+
+func TestComposer_SetPolicy(t *testing.T) {
+	values := []interface{}{
+		nil,
+		(*string)(nil),
+		"",
+		"text",
+		0,
+		1,
+		false,
+		true,
+		[]string{},
+		[]string(nil),
+		time.Time{},
+		sql.NullInt64{},
+		sql.NullInt64{Valid: true},
+		newAppearer(""),
+		pqcomp.Empty,
+	}
+	cases := map[string]struct {
+		policy   pqcomp.Policy
+		expected int
+	}{
+		"default":        {policy: pqcomp.DefaultPolicy, expected: 8},
+		"nil-only":       {policy: pqcomp.NilOnly, expected: 9},
+		"zero-is-absent": {policy: pqcomp.ZeroIsAbsent, expected: 5},
+	}
+
+	for hint, c := range cases {
+		comp := pqcomp.New(0, 0, 1).SetPolicy(c.policy)
+		child := comp.Compose()
+		for _, v := range values {
+			child.AddExpr("column", pqcomp.Equal, v)
+		}
+
+		if child.Len() != c.expected {
+			t.Errorf("%s: wrong number of expressions, expected %d but got %d", hint, c.expected, child.Len())
+		}
+	}
+}
+
+func TestComposer_AddExprWithPolicy(t *testing.T) {
+	comp := pqcomp.New(0, 0)
+	comp.AddExprWithPolicy("a", pqcomp.Equal, 0, pqcomp.ZeroIsAbsent)
+	comp.AddExprWithPolicy("b", pqcomp.Equal, 1, pqcomp.ZeroIsAbsent)
+	comp.AddExpr("c", pqcomp.Equal, 0)
+
+	expected := "WHERE b = $1 AND c = $2"
+	if got := comp.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+}
+
+func TestComposer_AddExpr_pointer(t *testing.T) {
+	var (
+		nilString *string
+		nilInt64  *int64
+		nilBool   *bool
+		nilUint   *uint
+		nilTime   *time.Time
+	)
+	text, number, flag, unsigned := "text", int64(0), false, uint(1)
+	textPtr := &text
+	ids := []int64{1, 2}
+
+	comp := pqcomp.New(0, 0)
+	comp.AddExpr("nil-string", pqcomp.Equal, nilString)
+	comp.AddExpr("nil-int64", pqcomp.Equal, nilInt64)
+	comp.AddExpr("nil-bool", pqcomp.Equal, nilBool)
+	comp.AddExpr("nil-uint", pqcomp.Equal, nilUint)
+	comp.AddExpr("nil-time", pqcomp.Equal, nilTime)
+	comp.AddExpr("nil-pointer-pointer", pqcomp.Equal, &nilString)
+	comp.AddExpr("zero-time", pqcomp.Equal, &time.Time{})
+	comp.AddExpr("string", pqcomp.Equal, &text)
+	comp.AddExpr("int64", pqcomp.Equal, &number)
+	comp.AddExpr("bool", pqcomp.Equal, &flag)
+	comp.AddExpr("uint", pqcomp.Equal, &unsigned)
+	comp.AddExpr("pointer-pointer", pqcomp.Equal, &textPtr)
+	comp.AddExpr("slice", pqcomp.In, &ids)
+
+	expected := []interface{}{"text", int64(0), false, uint(1), "text", int64(1), int64(2)}
+	if got := comp.Args(); !reflect.DeepEqual(expected, got) {
+		t.Errorf("wrong arguments, expected %v but got %v", expected, got)
+	}
+	if expected := "WHERE string = $1 AND int64 = $2 AND bool = $3 AND uint = $4 AND pointer-pointer = $5 AND slice IN ($6, $7)"; comp.Where() != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, comp.Where())
+	}
+}
+
 func TestComposer_Len(t *testing.T) {
 	comp := pqcomp.New(1, 1, 2)
 
@@ -216,6 +537,78 @@ func TestComposer_PlaceHolder(t *testing.T) {
 	}
 }
 
+func TestComposer_PlaceHolder_order(t *testing.T) {
+	comp, compA, compB := prepareComposers(2, 2)
+	comp.AddArg(10)
+	compC := compB.Compose()
+	compC.AddExpr("column", pqcomp.Equal, "value")
+
+	collect := func(c *pqcomp.Composer) (placeholders []string) {
+		c.Reset()
+		for c.Next() {
+			placeholders = append(placeholders, c.PlaceHolder())
+		}
+		return
+	}
+
+	for i := 0; i < 2; i++ {
+		if got := collect(compC); !reflect.DeepEqual(got, []string{"$6"}) {
+			t.Errorf("wrong placeholders for composer C, got %v", got)
+		}
+		if got := collect(compB); !reflect.DeepEqual(got, []string{"$4", "$5"}) {
+			t.Errorf("wrong placeholders for composer B, got %v", got)
+		}
+		if got := collect(compA); !reflect.DeepEqual(got, []string{"$2", "$3"}) {
+			t.Errorf("wrong placeholders for composer A, got %v", got)
+		}
+	}
+	if got := comp.Args(); len(got) != 6 || got[0] != 10 {
+		t.Errorf("wrong arguments, got %v", got)
+	}
+}
+
+func TestComposer_PlaceHolder_format(t *testing.T) {
+	cases := map[string]struct {
+		format   pqcomp.PlaceHolderFormat
+		expected string
+	}{
+		"dollar":   {format: pqcomp.Dollar, expected: "WHERE a = $2 AND b = $3"},
+		"question": {format: pqcomp.Question, expected: "WHERE a = ? AND b = ?"},
+		"atp":      {format: pqcomp.AtP, expected: "WHERE a = @p2 AND b = @p3"},
+		"colon":    {format: pqcomp.Colon, expected: "WHERE a = :2 AND b = :3"},
+	}
+
+	for hint, c := range cases {
+		comp := pqcomp.NewWithFormat(c.format, 1, 0, 2)
+		comp.AddArg(10)
+		where := comp.Compose()
+		where.AddExpr("a", pqcomp.Equal, 1)
+		where.AddExpr("b", pqcomp.Equal, 2)
+
+		if got := where.Where(); got != c.expected {
+			t.Errorf("%s: wrong where clause, expected %q but got %q", hint, c.expected, got)
+		}
+	}
+}
+
+func TestComposer_SetFormat(t *testing.T) {
+	comp := pqcomp.New(1, 0, 2)
+	comp.AddArg(10)
+	where := comp.Compose().SetFormat(pqcomp.AtP)
+	where.AddExpr("a", pqcomp.Equal, 1)
+
+	expected := "WHERE a = @p2"
+	if got := where.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+
+	comp.SetFormat(pqcomp.Question)
+	expected = "WHERE a = ?"
+	if got := where.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+}
+
 func TestComposer_Key(t *testing.T) {
 	lengthA, lengthB := 10, 20
 	_, compA, compB := prepareComposers(lengthA, lengthB)
@@ -257,6 +650,33 @@ func TestComposer_Expr(t *testing.T) {
 	}
 }
 
+func TestComposer_Expr_cursor(t *testing.T) {
+	comp := pqcomp.New(0, 1)
+	comp.AddExpr("column", pqcomp.GreaterThan, 1)
+
+	if _, err := comp.Expr(); err != pqcomp.ErrNoCursor {
+		t.Errorf("wrong error before Next, expected %v but got %v", pqcomp.ErrNoCursor, err)
+	}
+	if comp.Key() != "" || comp.Oper() != "" {
+		t.Errorf("key and operator should be empty before Next, got %q and %q", comp.Key(), comp.Oper())
+	}
+
+	for comp.Next() {
+		expr, err := comp.Expr()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		if expected := "column > $1"; expr.String() != expected {
+			t.Errorf("wrong expression, expected %q but got %q", expected, expr.String())
+		}
+	}
+
+	comp.Reset()
+	if _, err := comp.Expr(); err != pqcomp.ErrNoCursor {
+		t.Errorf("wrong error after Reset, expected %v but got %v", pqcomp.ErrNoCursor, err)
+	}
+}
+
 func TestComposer_ExprOptional(t *testing.T) {
 	var comp *pqcomp.Composer
 
@@ -322,6 +742,136 @@ func TestComposer_First(t *testing.T) {
 	}
 }
 
+func TestComposer_Where(t *testing.T) {
+	cases := map[string]struct {
+		exprs    [][]interface{}
+		expected string
+	}{
+		"none": {
+			expected: "",
+		},
+		"single": {
+			exprs:    [][]interface{}{{"a", pqcomp.Equal, 1}},
+			expected: "WHERE a = $1",
+		},
+		"multiple": {
+			exprs: [][]interface{}{
+				{"a", pqcomp.Equal, 1},
+				{"b", pqcomp.GreaterThan, 2},
+				{"c", pqcomp.Like, "%c%"},
+			},
+			expected: "WHERE a = $1 AND b > $2 AND c LIKE $3",
+		},
+		"skipped": {
+			exprs: [][]interface{}{
+				{"a", pqcomp.Equal, &sql.NullInt64{}},
+				{"b", pqcomp.GreaterThan, 2},
+			},
+			expected: "WHERE b > $1",
+		},
+	}
+
+	for hint, c := range cases {
+		comp := pqcomp.New(0, len(c.exprs))
+		for _, e := range c.exprs {
+			comp.AddExpr(e[0].(string), e[1].(string), e[2])
+		}
+
+		if got := comp.Where(); got != c.expected {
+			t.Errorf("%s: wrong where clause, expected %q but got %q", hint, c.expected, got)
+		}
+		if comp.Next() && !comp.First() {
+			t.Errorf("%s: cursor should be reset after rendering", hint)
+		}
+	}
+}
+
+func TestComposer_Where_groups(t *testing.T) {
+	comp := pqcomp.New(0, 1, 2, 1, 1)
+	comp.AddExpr("deleted", pqcomp.Equal, true)
+
+	status := comp.Compose().Join(pqcomp.Or)
+	status.AddExpr("status", pqcomp.Equal, "new")
+	status.AddExpr("status", pqcomp.Equal, "active")
+
+	comp.Compose().Not().AddExpr("age", pqcomp.GreaterThan, &sql.NullInt64{})
+
+	comp.Compose().Not().AddExpr("role", pqcomp.Equal, "admin")
+
+	expected := "WHERE deleted = $1 AND (status = $2 OR status = $3) AND NOT (role = $4)"
+	if got := comp.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+}
+
+func TestComposer_Where_not(t *testing.T) {
+	comp := pqcomp.New(0, 2).Join(pqcomp.Or).Not()
+	comp.AddExpr("a", pqcomp.Equal, 1)
+	comp.AddExpr("b", pqcomp.Equal, 2)
+
+	expected := "WHERE NOT (a = $1 OR b = $2)"
+	if got := comp.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+}
+
+func TestComposer_Join_invalid(t *testing.T) {
+	comp := pqcomp.New(0, 2).Join("OR 1 = 1 OR")
+	comp.AddExpr("a", pqcomp.Equal, 1)
+	comp.AddExpr("b", pqcomp.Equal, 2)
+
+	expected := "WHERE a = $1 AND b = $2"
+	if got := comp.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+	if comp.Err() == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestComposer_Set(t *testing.T) {
+	comp := pqcomp.New(0, 3)
+	comp.AddExpr("a", pqcomp.Equal, 1)
+	comp.AddExpr("b", pqcomp.Equal, &sql.NullString{})
+	comp.AddExpr("c", pqcomp.Equal, "c")
+
+	got, err := comp.Set()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := "SET a = $1, c = $2"; got != expected {
+		t.Errorf("wrong set clause, expected %q but got %q", expected, got)
+	}
+}
+
+func TestComposer_Set_empty(t *testing.T) {
+	comp := pqcomp.New(0, 0)
+	comp.AddExpr("a", pqcomp.Equal, &sql.NullString{})
+
+	if _, err := comp.Set(); err != pqcomp.ErrNothingToUpdate {
+		t.Errorf("wrong error, expected %v but got %v", pqcomp.ErrNothingToUpdate, err)
+	}
+}
+
+func TestComposer_Set_operator(t *testing.T) {
+	comp := pqcomp.New(0, 0)
+	comp.AddExpr("a", pqcomp.Equal, 1)
+	comp.AddExpr("b", pqcomp.GreaterThan, 2)
+
+	if _, err := comp.Set(); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestComposer_Set_duplicate(t *testing.T) {
+	comp := pqcomp.New(0, 0)
+	comp.AddExpr("tags", pqcomp.Equal, []string{"a", "b"})
+
+	if _, err := comp.Set(); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
 func prepareComposers(lengthA, lengthB int) (comp, compA, compB *pqcomp.Composer) {
 	comp = pqcomp.New(0, 0, lengthA, lengthB)
 
@@ -338,6 +888,16 @@ func prepareComposers(lengthA, lengthB int) (comp, compA, compB *pqcomp.Composer
 	return
 }
 
+type valuer string
+
+// Value implements driver.Valuer interface.
+func (v valuer) Value() (driver.Value, error) {
+	if v == "" {
+		return nil, nil
+	}
+	return string(v), nil
+}
+
 type appearer string
 
 func newAppearer(s string) *appearer {
@@ -0,0 +1,124 @@
+package pqcomp
+
+import "strings"
+
+// Select builds SELECT statement. It owns root Composer with child composers for WHERE clause,
+// HAVING clause and pagination, so placeholders of all of them are numbered consistently.
+type Select struct {
+	root, where, having, page *Composer
+	columns, joins, groupBy   []string
+	from                      string
+}
+
+// NewSelect allocates new SELECT statement builder for given columns.
+// If no columns are given, all of them are selected.
+func NewSelect(columns ...string) *Select {
+	root := New(0, 0, 0, 0, 0)
+	return &Select{
+		root:    root,
+		where:   root.Compose(),
+		having:  root.Compose(),
+		page:    root.Compose(),
+		columns: columns,
+	}
+}
+
+// Composer returns root composer of the statement. It can be used to set placeholder format using SetFormat,
+// policy, strict mode or to add static arguments, for example ones referenced by joins.
+// Static arguments are numbered before the arguments of any clause.
+func (s *Select) Composer() *Composer {
+	return s.root
+}
+
+// Columns adds columns to the list of selected columns.
+func (s *Select) Columns(columns ...string) *Select {
+	s.columns = append(s.columns, columns...)
+	return s
+}
+
+// From sets FROM clause, e.g. "users AS u".
+func (s *Select) From(from string) *Select {
+	s.from = from
+	return s
+}
+
+// Join adds JOIN clause as it is, e.g. "LEFT JOIN groups AS g ON g.id = u.group_id".
+func (s *Select) Join(join string) *Select {
+	s.joins = append(s.joins, join)
+	return s
+}
+
+// GroupBy adds columns to GROUP BY clause.
+func (s *Select) GroupBy(columns ...string) *Select {
+	s.groupBy = append(s.groupBy, columns...)
+	return s
+}
+
+// Where returns composer of WHERE clause.
+// Its ordering builder (see Composer.OrderBy and Composer.Seek) is rendered as ORDER BY clause.
+func (s *Select) Where() *Composer {
+	return s.where
+}
+
+// Having returns composer of HAVING clause.
+func (s *Select) Having() *Composer {
+	return s.having
+}
+
+// OrderBy returns ordering builder of WHERE clause composer.
+func (s *Select) OrderBy(columns map[string]string) *Order {
+	return s.where.OrderBy(columns)
+}
+
+// Paginate adds limit and offset, see Composer.Paginate. Maximum page size can be set on Page composer.
+func (s *Select) Paginate(limit, offset int64) {
+	s.page.Paginate(limit, offset)
+}
+
+// Page returns composer of LIMIT and OFFSET clause.
+func (s *Select) Page() *Composer {
+	return s.page
+}
+
+// ToSQL renders whole statement and returns it together with arguments in order matching placeholders.
+// It returns the first error recorded by any of the composers.
+func (s *Select) ToSQL() (string, []interface{}, error) {
+	if err := s.root.Err(); err != nil {
+		return "", nil, err
+	}
+
+	var buf strings.Builder
+	buf.WriteString("SELECT ")
+	if len(s.columns) == 0 {
+		buf.WriteString("*")
+	} else {
+		buf.WriteString(strings.Join(s.columns, ", "))
+	}
+	if s.from != "" {
+		buf.WriteString(" FROM ")
+		buf.WriteString(s.from)
+	}
+	for _, join := range s.joins {
+		buf.WriteString(" ")
+		buf.WriteString(join)
+	}
+	writeClause(&buf, s.where.Where())
+	if len(s.groupBy) > 0 {
+		buf.WriteString(" GROUP BY ")
+		buf.WriteString(strings.Join(s.groupBy, ", "))
+	}
+	writeClause(&buf, s.having.Having())
+	if s.where.order != nil {
+		writeClause(&buf, s.where.order.String())
+	}
+	writeClause(&buf, s.page.Pagination())
+
+	return buf.String(), s.root.Args(), nil
+}
+
+func writeClause(buf *strings.Builder, clause string) {
+	if clause != "" {
+		buf.WriteString(" ")
+		buf.WriteString(clause)
+	}
+}
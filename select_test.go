@@ -0,0 +1,95 @@
+package pqcomp_test
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/piotrkowalczuk/pqcomp"
+)
+
+func TestSelect_ToSQL(t *testing.T) {
+	sel := pqcomp.NewSelect("u.id", "u.first_name").
+		Columns("count(g.id)").
+		From("users AS u").
+		Join("LEFT JOIN groups AS g ON g.user_id = u.id AND g.kind = $1").
+		GroupBy("u.id", "u.first_name")
+	sel.Composer().AddArg("admin")
+
+	sel.Where().AddExpr("u.first_name", pqcomp.Like, "j%")
+	sel.Where().AddExpr("u.age", pqcomp.GreaterThan, &sql.NullInt64{})
+	status := sel.Where().Compose().Join(pqcomp.Or)
+	status.AddExpr("u.status", pqcomp.Equal, "new")
+	status.AddExpr("u.status", pqcomp.Equal, "active")
+	sel.Having().AddExpr("count(g.id)", pqcomp.GreaterThan, 1)
+	if err := sel.OrderBy(map[string]string{"name": "u.first_name"}).Parse("-name"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	sel.Page().SetMaxLimit(100)
+	sel.Paginate(1000, 20)
+
+	query, args, err := sel.ToSQL()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedQuery := "SELECT u.id, u.first_name, count(g.id) FROM users AS u " +
+		"LEFT JOIN groups AS g ON g.user_id = u.id AND g.kind = $1 " +
+		"WHERE u.first_name LIKE $2 AND (u.status = $3 OR u.status = $4) " +
+		"GROUP BY u.id, u.first_name HAVING count(g.id) > $5 " +
+		"ORDER BY u.first_name DESC LIMIT $6 OFFSET $7"
+	if query != expectedQuery {
+		t.Errorf("wrong query, expected:\n%s\nbut got:\n%s", expectedQuery, query)
+	}
+	expectedArgs := []interface{}{"admin", "j%", "new", "active", 1, int64(100), int64(20)}
+	if !reflect.DeepEqual(expectedArgs, args) {
+		t.Errorf("wrong arguments, expected %v but got %v", expectedArgs, args)
+	}
+}
+
+func TestSelect_ToSQL_format(t *testing.T) {
+	sel := pqcomp.NewSelect().From("users AS u")
+	sel.Composer().SetFormat(pqcomp.Question)
+	sel.Paginate(10, 0)
+	sel.Having().AddExpr("count(*)", pqcomp.GreaterThan, 1)
+	sel.Where().AddExpr("u.first_name", pqcomp.Like, "j%")
+	sel.GroupBy("u.id")
+
+	query, args, err := sel.ToSQL()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedQuery := "SELECT * FROM users AS u WHERE u.first_name LIKE ? GROUP BY u.id HAVING count(*) > ? LIMIT ?"
+	if query != expectedQuery {
+		t.Errorf("wrong query, expected:\n%s\nbut got:\n%s", expectedQuery, query)
+	}
+	expectedArgs := []interface{}{"j%", 1, int64(10)}
+	if !reflect.DeepEqual(expectedArgs, args) {
+		t.Errorf("wrong arguments, expected %v but got %v", expectedArgs, args)
+	}
+}
+
+func TestSelect_ToSQL_minimal(t *testing.T) {
+	query, args, err := pqcomp.NewSelect().From("users").ToSQL()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := "SELECT * FROM users"; query != expected {
+		t.Errorf("wrong query, expected %q but got %q", expected, query)
+	}
+	if len(args) != 0 {
+		t.Errorf("unexpected arguments: %v", args)
+	}
+}
+
+func TestSelect_ToSQL_error(t *testing.T) {
+	sel := pqcomp.NewSelect().From("users")
+	sel.Composer().SetStrict(true)
+	sel.Where().AddExpr("1=1; --", pqcomp.Equal, 1)
+
+	if _, _, err := sel.ToSQL(); !errors.Is(err, pqcomp.ErrInvalidIdent) {
+		t.Errorf("wrong error, expected %v but got %v", pqcomp.ErrInvalidIdent, err)
+	}
+}
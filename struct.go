@@ -0,0 +1,111 @@
+package pqcomp
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// TagName is the name of struct tag that is read by AddStruct.
+const TagName = "pqcomp"
+
+// ErrNotStruct is returned by AddStruct if given value is not a struct or a pointer to a struct.
+var ErrNotStruct = errors.New("pqcomp: value is not a struct")
+
+// plans caches struct field plan for each type passed to AddStruct.
+var plans sync.Map
+
+type fieldPlan struct {
+	index         []int
+	key, operator string
+}
+
+// AddStruct adds expression for each struct field that has pqcomp tag,
+// using the same rules as AddExpr. Tag consists of key and optional operator separated by comma,
+// if operator is omitted Equal is used:
+//
+//	type UserFilter struct {
+//		Name *string `pqcomp:"u.name"`
+//		Age  *int64  `pqcomp:"u.age,>="`
+//	}
+//
+// Fields without the tag or with tag equal to "-" are ignored,
+// fields of embedded structs are walked as if they were declared in the outer struct.
+// Nil pointer to a struct adds nothing.
+func (c *Composer) AddStruct(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			if rv.Type().Elem().Kind() != reflect.Struct {
+				return ErrNotStruct
+			}
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+
+	for _, f := range planFor(rv.Type()) {
+		fv, err := rv.FieldByIndexErr(f.index)
+		if err != nil {
+			// nil pointer to embedded struct
+			continue
+		}
+		c.AddExpr(f.key, f.operator, fv.Interface())
+	}
+
+	return nil
+}
+
+func planFor(t reflect.Type) []fieldPlan {
+	if p, ok := plans.Load(t); ok {
+		return p.([]fieldPlan)
+	}
+
+	p, _ := plans.LoadOrStore(t, buildPlan(t, nil, map[reflect.Type]bool{}))
+	return p.([]fieldPlan)
+}
+
+// buildPlan collects tagged fields of t and embedded structs.
+// Visited holds types that are being walked, so type that embeds itself is walked only once.
+func buildPlan(t reflect.Type, index []int, visited map[reflect.Type]bool) (plan []fieldPlan) {
+	visited[t] = true
+	defer delete(visited, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		idx := append(append(make([]int, 0, len(index)+1), index...), i)
+
+		tag, ok := sf.Tag.Lookup(TagName)
+		if !ok {
+			if sf.Anonymous {
+				ft := sf.Type
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct && !visited[ft] {
+					plan = append(plan, buildPlan(ft, idx, visited)...)
+				}
+			}
+			continue
+		}
+		if tag == "-" || !sf.IsExported() {
+			continue
+		}
+
+		key, operator := tag, Equal
+		if i := strings.Index(tag, ","); i != -1 {
+			key, operator = strings.TrimSpace(tag[:i]), strings.TrimSpace(tag[i+1:])
+		}
+		if key == "" {
+			continue
+		}
+
+		plan = append(plan, fieldPlan{index: idx, key: key, operator: operator})
+	}
+
+	return
+}
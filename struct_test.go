@@ -0,0 +1,100 @@
+package pqcomp_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/piotrkowalczuk/pqcomp"
+)
+
+type pagination struct {
+	CreatedAfter *time.Time `pqcomp:"u.created_at,>"`
+}
+
+type userFilter struct {
+	*pagination
+	Name     *string        `pqcomp:"u.name"`
+	Age      *int64         `pqcomp:"u.age, >="`
+	IDs      []int64        `pqcomp:"u.id,IN"`
+	Email    sql.NullString `pqcomp:"u.email,LIKE"`
+	Ignored  string         `pqcomp:"-"`
+	Untagged string
+	private  string `pqcomp:"u.private"`
+}
+
+func TestComposer_AddStruct(t *testing.T) {
+	name, age := "john", int64(18)
+	created := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		filter   interface{}
+		expected string
+	}{
+		"empty": {
+			filter:   userFilter{},
+			expected: "",
+		},
+		"nil": {
+			filter:   (*userFilter)(nil),
+			expected: "",
+		},
+		"full": {
+			filter: &userFilter{
+				pagination: &pagination{CreatedAfter: &created},
+				Name:       &name,
+				Age:        &age,
+				IDs:        []int64{1, 2},
+				Email:      sql.NullString{String: "%@example.com", Valid: true},
+				Ignored:    "ignored",
+				Untagged:   "untagged",
+				private:    "private",
+			},
+			expected: "WHERE u.created_at > $1 AND u.name = $2 AND u.age >= $3 AND u.id IN ($4, $5) AND u.email LIKE $6",
+		},
+		"partial": {
+			filter: userFilter{
+				Age: &age,
+			},
+			expected: "WHERE u.age >= $1",
+		},
+	}
+
+	for hint, c := range cases {
+		comp := pqcomp.New(0, 0)
+		if err := comp.AddStruct(c.filter); err != nil {
+			t.Errorf("%s: unexpected error: %s", hint, err.Error())
+			continue
+		}
+		if got := comp.Where(); got != c.expected {
+			t.Errorf("%s: wrong where clause, expected %q but got %q", hint, c.expected, got)
+		}
+	}
+}
+
+func TestComposer_AddStruct_notStruct(t *testing.T) {
+	comp := pqcomp.New(0, 0)
+	if err := comp.AddStruct(1); err != pqcomp.ErrNotStruct {
+		t.Errorf("wrong error, expected %v but got %v", pqcomp.ErrNotStruct, err)
+	}
+	if err := comp.AddStruct((*int)(nil)); err != pqcomp.ErrNotStruct {
+		t.Errorf("wrong error, expected %v but got %v", pqcomp.ErrNotStruct, err)
+	}
+}
+
+type node struct {
+	*node
+	X int `pqcomp:"x"`
+}
+
+func TestComposer_AddStruct_recursive(t *testing.T) {
+	comp := pqcomp.New(0, 0)
+	if err := comp.AddStruct(&node{node: &node{X: 2}, X: 1}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := "WHERE x = $1"
+	if got := comp.Where(); got != expected {
+		t.Errorf("wrong where clause, expected %q but got %q", expected, got)
+	}
+}